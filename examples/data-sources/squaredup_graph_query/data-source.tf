@@ -0,0 +1,23 @@
+data "squaredup_graph_query" "lambda_functions" {
+  query = "g.V().has('__configId', configId).has('type', type).limit(limit).valueMap(true)"
+  bindings = {
+    configId = "datasource-id"
+    type     = "function"
+    limit    = 10
+  }
+}
+
+# Preview the nodes an advanced scope would match and feed their IDs into other resources
+data "squaredup_graph_query" "advanced_scope_preview" {
+  query            = "g.V().has('__configId', configId).has('sourceType', within(sourceTypes)).id()"
+  wait_for_results = true
+  timeout          = 120
+  bindings = {
+    configId    = "datasource-id"
+    sourceTypes = ["AWS::Lambda::Function", "AWS::EC2::Instance"]
+  }
+}
+
+output "advanced_scope_node_ids" {
+  value = data.squaredup_graph_query.advanced_scope_preview.results
+}
@@ -0,0 +1,19 @@
+data "squaredup_nodes" "cluster" {
+  data_source_id = "datasource-id"
+  node_name      = "production-cluster"
+}
+
+# Everything hosted on the cluster, up to two hops away
+data "squaredup_node_neighbors" "hosted_on_cluster" {
+  node_id     = data.squaredup_nodes.cluster.node_properties[0].id
+  direction   = "out"
+  edge_labels = ["hosts"]
+  depth       = 2
+}
+
+resource "squaredup_scope" "hosted_on_cluster" {
+  display_name = "Hosted on production-cluster"
+  scope_type   = "fixed"
+  workspace_id = "workspace-id"
+  node_ids     = [for node in data.squaredup_node_neighbors.hosted_on_cluster.nodes : node.id]
+}
@@ -18,3 +18,23 @@ data "squaredup_nodes" "acommon_node_by_id" {
   data_source_id = squaredup_datasource.sample_data_source.id
   node_source_id = "sample-server-2"
 }
+
+data "squaredup_nodes" "existing_node_fail_fast" {
+  data_source_id = squaredup_datasource.sample_data_source.id
+  node_name      = "account-common-lambda"
+  wait_for_nodes = false
+}
+
+data "squaredup_nodes" "prod_hosts_in_eu" {
+  data_source_id   = squaredup_datasource.sample_data_source.id
+  node_type        = "host"
+  node_name_prefix = "sample-server"
+  properties = {
+    environment = "prod"
+  }
+  property_regex = {
+    region = "^eu-"
+  }
+  limit          = 10
+  wait_for_nodes = false
+}
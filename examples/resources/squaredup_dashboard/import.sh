@@ -1,2 +1,3 @@
 # Dashboards can be imported by specifying dashboard id.
+# The dashboard content is imported as dashboard_template, without template bindings.
 terraform import squaredup_dashboard.example dash-123
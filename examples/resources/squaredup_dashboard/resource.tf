@@ -188,15 +188,90 @@ resource "squaredup_dashboard" "sample_dashboard" {
   "columns": 4
 }
 EOT
-  template_bindings = jsonencode({
+  bindings = {
     sample_data_source_id = squaredup_datasource.sample_data_source.id
     cloud_watch_logs_id   = local.logs_data_stream.id
     perf_lambda_errors_id = local.perf_lambda_errors_data_stream.id
     cost_data_stream      = local.cost_data_stream.id
     acommon_node_id       = data.squaredup_nodes.acommon_node.node_properties[0].id
     cost_threshold        = 500
-  })
+  }
   workspace_id = squaredup_workspace.application_workspace.id
   display_name = "Sample Dashboard"
   timeframe    = "last12hours"
 }
+
+# Go template engine: one tile per scope, with stable tile IDs
+resource "squaredup_dashboard" "scopes_dashboard" {
+  template_engine    = "gotemplate"
+  dashboard_template = <<EOT
+{
+  "_type": "layout/grid",
+  "columns": {{ default 4 .columns }},
+  "version": 1,
+  "contents": [
+  {{- range $i, $scope := .scopes }}{{ if $i }},{{ end }}
+    {
+      "i": "{{ uuid "scope-tile" $scope.name }}",
+      "x": {{ $i }}, "y": 0, "w": 1, "h": 2,
+      "config": {
+        "_type": "tile/text",
+        "title": {{ toJson $scope.name }},
+        "visualisation": {
+          "config": {
+            "content": {{ toJson (printf "Nodes: %d" (len $scope.node_ids)) }}
+          }
+        }
+      }
+    }
+  {{- end }}
+  ]
+}
+EOT
+  bindings = {
+    scopes = [
+      { name = "Functions", node_ids = [data.squaredup_nodes.acommon_node.node_properties[0].id] },
+      { name = "Servers", node_ids = [] },
+    ]
+  }
+  workspace_id = squaredup_workspace.application_workspace.id
+  display_name = "Scopes Dashboard"
+}
+
+# Tile blocks: the provider compiles the tiles into the dashboard content
+resource "squaredup_dashboard" "tiles_dashboard" {
+  workspace_id = squaredup_workspace.application_workspace.id
+  display_name = "Tiles Dashboard"
+  timeframe    = "last24hours"
+
+  tile {
+    title          = "CloudWatch Logs"
+    data_source_id = squaredup_datasource.sample_data_source.id
+    data_stream_id = local.logs_data_stream.id
+    visualisation  = "data-stream-table"
+    x              = 0
+    y              = 0
+    w              = 2
+    h              = 3
+  }
+
+  tile {
+    id             = "account-common-lambda-cost"
+    title          = "Account Common Lambda Cost"
+    data_source_id = squaredup_datasource.sample_data_source.id
+    data_stream_id = local.cost_data_stream.id
+    node_ids       = [data.squaredup_nodes.acommon_node.node_properties[0].id]
+    visualisation  = "data-stream-scalar"
+    timeframe      = "last7days"
+    x              = 2
+    y              = 0
+    w              = 2
+    h              = 3
+    monitor = {
+      column            = "data.cost.value_sum"
+      error_threshold   = 500
+      warning_threshold = 400
+      frequency         = 720
+    }
+  }
+}
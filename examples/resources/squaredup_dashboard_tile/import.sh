@@ -0,0 +1,2 @@
+# Dashboard Tile can be imported by specifying the dashboard id and the tile id.
+terraform import squaredup_dashboard_tile.example dash-123,tile-guid
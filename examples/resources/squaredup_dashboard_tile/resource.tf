@@ -0,0 +1,59 @@
+data "squaredup_datasources" "sample_data" {
+  data_source_name = "Sample Data"
+}
+
+resource "squaredup_datasource" "sample_data_source" {
+  display_name     = "Sample Data"
+  data_source_name = data.squaredup_datasources.sample_data.plugins[0].display_name
+}
+
+data "squaredup_data_streams" "sample_data_streams" {
+  data_source_id = data.squaredup_datasources.sample_data.plugins[0].id
+}
+
+resource "squaredup_workspace" "shared_workspace" {
+  display_name      = "Shared Services"
+  description       = "Workspace shared by several teams"
+  datasources_links = [squaredup_datasource.sample_data_source.id]
+}
+
+# The shared dashboard keeps the tiles other teams add to it
+resource "squaredup_dashboard" "shared_dashboard" {
+  display_name          = "Shared Overview"
+  workspace_id          = squaredup_workspace.shared_workspace.id
+  ignore_external_tiles = true
+  dashboard_template = jsonencode({
+    _type    = "layout/grid"
+    columns  = 4
+    version  = 1
+    contents = []
+  })
+}
+
+# A tile defined with structured attributes
+resource "squaredup_dashboard_tile" "logs" {
+  dashboard_id   = squaredup_dashboard.shared_dashboard.id
+  title          = "Logs"
+  data_source_id = squaredup_datasource.sample_data_source.id
+  data_stream_id = data.squaredup_data_streams.sample_data_streams.data_streams[0].id
+  visualisation  = "data-stream-table"
+  x              = 0
+  y              = 0
+  w              = 2
+  h              = 3
+}
+
+# A tile defined with the raw tile configuration
+resource "squaredup_dashboard_tile" "notes" {
+  dashboard_id = squaredup_dashboard.shared_dashboard.id
+  x            = 2
+  y            = 0
+  w            = 2
+  h            = 3
+  tile_config = jsonencode({
+    _type         = "tile/text"
+    title         = "Notes"
+    description   = ""
+    visualisation = { config = { content = "Owned by the integrations team" } }
+  })
+}
@@ -34,10 +34,10 @@ resource "squaredup_dashboard_variable" "example_all_variable" {
 }
 
 resource "squaredup_dashboard" "all_objects" {
-  workspace_id          = squaredup_workspace.application_workspace.id
-  display_name          = "All Objects"
-  dashboard_variable_id = squaredup_dashboard_variable.example_all_variable.id
-  dashboard_template    = <<EOT
+  workspace_id           = squaredup_workspace.application_workspace.id
+  display_name           = "All Objects"
+  dashboard_variable_ids = [squaredup_dashboard_variable.example_all_variable.id]
+  dashboard_template     = <<EOT
 {
   "_type": "layout/grid",
   "contents": [
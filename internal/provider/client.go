@@ -1,48 +1,151 @@
 package provider
 
 import (
+	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand"
+	"net"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	authTransportHeader = "header"
+	authTransportQuery  = "query"
+)
+
+const (
+	defaultMaxRetries     = 4
+	defaultRetryMaxWait   = 30 * time.Second
+	defaultRequestTimeout = 120 * time.Second
+	retryBaseWait         = 1 * time.Second
 )
 
 type SquaredUpClient struct {
-	baseURL    string
-	apiKey     string
-	httpClient *http.Client
-	version    string
+	baseURL       string
+	apiKey        string
+	authTransport string
+	httpClient    *http.Client
+	version       string
+	maxRetries    int
+	retryMaxWait  time.Duration
+
+	// limiter is shared by every resource and data source of the provider
+	// instance so that large applies do not trip SquaredUp throttling.
+	limiter *requestLimiter
+
+	catalogCache responseCache
+
+	// workspaceLocks serializes mutations of the same workspace. Several
+	// resources (workspace, workspace alerts, dashboard ordering) PATCH the
+	// same workspace object and must not interleave.
+	workspaceLocks keyedMutex
+
+	// dashboardLocks serializes changes to the same dashboard, which
+	// squaredup_dashboard_tile resources edit with a read-modify-write.
+	dashboardLocks keyedMutex
 }
 
-func NewSquaredUpClient(region string, apiKey string, version string) (*SquaredUpClient, error) {
-	baseURL, err := determineBaseURL(region)
+// SquaredUpClientOptions holds the optional provider settings used to build a SquaredUpClient.
+type SquaredUpClientOptions struct {
+	AuthTransport             string
+	MaxRetries                int
+	RetryMaxWait              time.Duration
+	HTTPProxy                 string
+	CACertPEM                 string
+	CACertFile                string
+	RequestTimeout            time.Duration
+	AllowInsecureHTTP         bool
+	SkipCredentialsValidation bool
+	MaxConcurrentRequests     int
+	RequestsPerSecond         int
+}
+
+func NewSquaredUpClient(ctx context.Context, region string, apiKey string, version string, options SquaredUpClientOptions) (*SquaredUpClient, error) {
+	baseURL, err := determineBaseURL(region, options.AllowInsecureHTTP)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", baseURL+"/api/plugins/latest", nil)
+	transport, err := newHTTPTransport(options)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %v", err)
+		return nil, err
 	}
 
-	client := &http.Client{}
+	requestTimeout := options.RequestTimeout
+	if requestTimeout <= 0 {
+		requestTimeout = defaultRequestTimeout
+	}
+
+	client := &http.Client{
+		Timeout: requestTimeout,
+		Transport: &loggingTransport{
+			next:    transport,
+			secrets: []string{apiKey},
+		},
+	}
 
 	squaredUpClient := &SquaredUpClient{
-		baseURL:    baseURL,
-		apiKey:     apiKey,
-		httpClient: client,
-		version:    version,
+		baseURL:       baseURL,
+		apiKey:        apiKey,
+		authTransport: options.AuthTransport,
+		httpClient:    client,
+		version:       version,
+		maxRetries:    options.MaxRetries,
+		retryMaxWait:  options.RetryMaxWait,
+		limiter:       newRequestLimiter(options.MaxConcurrentRequests, options.RequestsPerSecond),
+		catalogCache:  responseCache{ttl: catalogCacheTTL},
 	}
 
-	_, err = squaredUpClient.doRequest(req)
-	if err != nil {
-		return nil, fmt.Errorf("invalid api key with the provided region. check the api key and region and try again")
+	if squaredUpClient.authTransport == "" {
+		squaredUpClient.authTransport = authTransportQuery
+	}
+
+	if squaredUpClient.maxRetries < 0 {
+		squaredUpClient.maxRetries = 0
+	}
+
+	if squaredUpClient.retryMaxWait <= 0 {
+		squaredUpClient.retryMaxWait = defaultRetryMaxWait
+	}
+
+	if !options.SkipCredentialsValidation {
+		err = squaredUpClient.validateCredentials(ctx)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return squaredUpClient, nil
 }
 
-func determineBaseURL(region string) (string, error) {
+// validateCredentials probes the API to check the api key and region. Auth
+// failures are reported separately from network and TLS failures so that an
+// unreachable endpoint is not mistaken for a bad key.
+func (c *SquaredUpClient) validateCredentials(ctx context.Context) error {
+	// The probe also primes the plugin catalog cache used by data sources
+	_, err := c.getCatalog(ctx, "/api/plugins/latest")
+	if err == nil {
+		return nil
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden) {
+		return fmt.Errorf("invalid api key with the provided region. check the api key and region and try again: %w", err)
+	}
+
+	return fmt.Errorf("unable to validate credentials against %s: %w", c.baseURL, err)
+}
+
+func determineBaseURL(region string, allowInsecureHTTP bool) (string, error) {
 	if region == "us" {
 		return "https://api.squaredup.com", nil
 	} else if region == "eu" {
@@ -50,14 +153,24 @@ func determineBaseURL(region string) (string, error) {
 	} else if strings.HasPrefix(region, "https://") {
 		region = strings.TrimSuffix(region, "/")
 		return region, nil
+	} else if strings.HasPrefix(region, "http://") {
+		if !allowInsecureHTTP {
+			return "", fmt.Errorf("insecure http endpoint %s is not allowed. set allow_insecure_http to use it", region)
+		}
+		region = strings.TrimSuffix(region, "/")
+		return region, nil
 	}
 	return "", fmt.Errorf("unsupported region or URL scheme: %s", region)
 }
 
 func (c *SquaredUpClient) doRequest(req *http.Request) ([]byte, error) {
-	q := req.URL.Query()
-	q.Add("apiKey", c.apiKey)
-	req.URL.RawQuery = q.Encode()
+	if c.authTransport == authTransportHeader {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	} else {
+		q := req.URL.Query()
+		q.Add("apiKey", c.apiKey)
+		req.URL.RawQuery = q.Encode()
+	}
 
 	req.Header.Add("User-Agent", fmt.Sprintf("SquaredUp-Terraform-Provider/%s", c.version))
 
@@ -65,20 +178,219 @@ func (c *SquaredUpClient) doRequest(req *http.Request) ([]byte, error) {
 		req.Header.Add("Content-Type", "application/json")
 	}
 
+	body, err := c.doWithRetry(req)
+	if err != nil {
+		return nil, c.scrubError(err)
+	}
+
+	return body, nil
+}
+
+func (c *SquaredUpClient) doWithRetry(req *http.Request) ([]byte, error) {
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		start := time.Now()
+		release, err := c.limiter.Acquire(req.Context())
+		if err != nil {
+			return nil, err
+		}
+		if queueWait := time.Since(start); queueWait >= time.Millisecond {
+			tflog.Debug(req.Context(), "Waited for SquaredUp API request slot", map[string]interface{}{
+				"http_method":   req.Method,
+				"http_path":     req.URL.Path,
+				"queue_wait_ms": queueWait.Milliseconds(),
+			})
+		}
+
+		body, res, err := c.send(req)
+		release()
+		if err == nil {
+			return body, nil
+		}
+
+		if attempt >= c.maxRetries || !shouldRetry(req, res, err) {
+			return nil, err
+		}
+
+		// A request body that cannot be rewound must not be replayed
+		if req.Body != nil && req.GetBody == nil {
+			return nil, err
+		}
+
+		wait := c.retryWait(attempt, res)
+		if wait < 0 {
+			return nil, err
+		}
+
+		tflog.Debug(req.Context(), "Retrying SquaredUp API request", map[string]interface{}{
+			"http_method":   req.Method,
+			"http_path":     req.URL.Path,
+			"retry_attempt": attempt + 1,
+			"retry_wait_ms": wait.Milliseconds(),
+			"error":         c.scrubError(err).Error(),
+		})
+
+		if err := sleepWithContext(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// scrubError removes the API key from the error text so that it never ends
+// up in a diagnostic. The original error is still available to errors.As.
+func (c *SquaredUpClient) scrubError(err error) error {
+	if c.apiKey == "" {
+		return err
+	}
+
+	msg := err.Error()
+	scrubbed := msg
+	for _, secret := range []string{c.apiKey, url.QueryEscape(c.apiKey)} {
+		scrubbed = strings.ReplaceAll(scrubbed, secret, "REDACTED")
+	}
+
+	if scrubbed == msg {
+		return err
+	}
+
+	return &scrubbedError{err: err, msg: scrubbed}
+}
+
+type scrubbedError struct {
+	err error
+	msg string
+}
+
+func (e *scrubbedError) Error() string {
+	return e.msg
+}
+
+func (e *scrubbedError) Unwrap() error {
+	return e.err
+}
+
+// sleepWithContext waits for d to elapse, returning early with the context
+// error if ctx is cancelled first.
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// send performs a single attempt of req. The response is returned alongside a
+// non-nil error when the API answered with an unexpected status code.
+func (c *SquaredUpClient) send(req *http.Request) ([]byte, *http.Response, error) {
 	res, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer res.Body.Close()
 
 	body, err := io.ReadAll(res.Body)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if res.StatusCode != 200 && res.StatusCode != 201 && res.StatusCode != 204 {
-		return nil, fmt.Errorf("status: %d, body: %s", res.StatusCode, body)
+		return nil, res, newAPIError(res, body)
 	}
 
-	return body, nil
+	return body, res, nil
+}
+
+// shouldRetry reports whether a failed attempt can safely be sent again.
+// Idempotent requests are retried on throttling, server errors and transport
+// failures other than certificate errors. Other requests are only retried
+// when the failure proves the request never took effect: a 429 response or a
+// connection that was never established.
+func shouldRetry(req *http.Request, res *http.Response, err error) bool {
+	if res == nil {
+		var certErr *tls.CertificateVerificationError
+		if errors.As(err, &certErr) {
+			return false
+		}
+
+		var opErr *net.OpError
+		if errors.As(err, &opErr) && opErr.Op == "dial" {
+			return true
+		}
+		return isIdempotent(req.Method) && req.Context().Err() == nil
+	}
+
+	if res.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	if !isIdempotent(req.Method) {
+		return false
+	}
+
+	switch res.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// retryWait returns how long to wait before the next attempt, or a negative
+// duration when the server asked us to wait longer than retryMaxWait.
+func (c *SquaredUpClient) retryWait(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if retryAfter, ok := parseRetryAfter(res.Header.Get("Retry-After")); ok {
+			if retryAfter > c.retryMaxWait {
+				return -1
+			}
+			return retryAfter
+		}
+	}
+
+	backoff := retryBaseWait << attempt
+	if backoff <= 0 || backoff > c.retryMaxWait {
+		backoff = c.retryMaxWait
+	}
+
+	// Full jitter spreads out retries from resources that failed together
+	return time.Duration(rand.Int63n(int64(backoff)) + 1)
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
 }
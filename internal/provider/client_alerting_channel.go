@@ -1,18 +1,19 @@
 package provider
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"strings"
 )
 
-func (c *SquaredUpClient) CreateAlertingChannel(alertChannel AlertingChannel) (*AlertingChannel, error) {
+func (c *SquaredUpClient) CreateAlertingChannel(ctx context.Context, alertChannel AlertingChannel) (*AlertingChannel, error) {
 	rb, err := json.Marshal(alertChannel)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", c.baseURL+"/api/alerting/channels", strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/api/alerting/channels", strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
@@ -31,8 +32,8 @@ func (c *SquaredUpClient) CreateAlertingChannel(alertChannel AlertingChannel) (*
 	return &newAlertChannel, nil
 }
 
-func (c *SquaredUpClient) GetAlertingChannel(alertChannelId string) (*AlertingChannel, error) {
-	req, err := http.NewRequest("GET", c.baseURL+"/api/alerting/channels/"+alertChannelId, nil)
+func (c *SquaredUpClient) GetAlertingChannel(ctx context.Context, alertChannelId string) (*AlertingChannel, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/api/alerting/channels/"+alertChannelId, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -51,13 +52,13 @@ func (c *SquaredUpClient) GetAlertingChannel(alertChannelId string) (*AlertingCh
 	return &alertChannel, nil
 }
 
-func (c *SquaredUpClient) UpdateAlertingChannel(alertChannelId string, alertChannel AlertingChannel) error {
+func (c *SquaredUpClient) UpdateAlertingChannel(ctx context.Context, alertChannelId string, alertChannel AlertingChannel) error {
 	rb, err := json.Marshal(alertChannel)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("PUT", c.baseURL+"/api/alerting/channels/"+alertChannelId, strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", c.baseURL+"/api/alerting/channels/"+alertChannelId, strings.NewReader(string(rb)))
 	if err != nil {
 		return err
 	}
@@ -70,8 +71,8 @@ func (c *SquaredUpClient) UpdateAlertingChannel(alertChannelId string, alertChan
 	return nil
 }
 
-func (c *SquaredUpClient) DeleteAlertingChannel(alertChannelId string) error {
-	req, err := http.NewRequest("DELETE", c.baseURL+"/api/alerting/channels/"+alertChannelId, nil)
+func (c *SquaredUpClient) DeleteAlertingChannel(ctx context.Context, alertChannelId string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.baseURL+"/api/alerting/channels/"+alertChannelId, nil)
 	if err != nil {
 		return err
 	}
@@ -1,18 +1,13 @@
 package provider
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
 )
 
-func (c *SquaredUpClient) GetAlertingChannelTypes(displayName string) ([]AlertingChannelType, error) {
-	req, err := http.NewRequest("GET", c.baseURL+"/api/alerting/channeltypes", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	body, err := c.doRequest(req)
+func (c *SquaredUpClient) GetAlertingChannelTypes(ctx context.Context, displayName string) ([]AlertingChannelType, error) {
+	body, err := c.getCatalog(ctx, "/api/alerting/channeltypes")
 	if err != nil {
 		return nil, err
 	}
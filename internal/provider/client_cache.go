@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// catalogCacheTTL is how long read-only catalog responses, such as the plugin
+// list, are reused before they are fetched again.
+const catalogCacheTTL = 10 * time.Minute
+
+// responseCache memoizes GET responses for read-only catalog endpoints.
+// Concurrent lookups of the same path share a single request.
+type responseCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]*responseCacheEntry
+}
+
+type responseCacheEntry struct {
+	done    chan struct{}
+	body    []byte
+	err     error
+	expires time.Time
+}
+
+// getCatalog returns the body of a GET request to path, served from the
+// cache when a fresh response is available.
+func (c *SquaredUpClient) getCatalog(ctx context.Context, path string) ([]byte, error) {
+	return c.catalogCache.get(ctx, path, func() ([]byte, error) {
+		req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+path, nil)
+		if err != nil {
+			return nil, err
+		}
+		return c.doRequest(req)
+	})
+}
+
+func (rc *responseCache) get(ctx context.Context, key string, fetch func() ([]byte, error)) ([]byte, error) {
+	for {
+		rc.mu.Lock()
+		if rc.entries == nil {
+			rc.entries = make(map[string]*responseCacheEntry)
+		}
+
+		entry, ok := rc.entries[key]
+		if ok {
+			rc.mu.Unlock()
+
+			select {
+			case <-entry.done:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+
+			if entry.err == nil && time.Now().Before(entry.expires) {
+				tflog.Trace(ctx, "Using cached SquaredUp API response", map[string]interface{}{
+					"http_path": key,
+				})
+				return entry.body, nil
+			}
+
+			// The shared request failed or has expired. Only repeat it if it
+			// was cancelled by its own caller rather than by the API.
+			if entry.err != nil && !errors.Is(entry.err, context.Canceled) && !errors.Is(entry.err, context.DeadlineExceeded) {
+				return nil, entry.err
+			}
+
+			rc.mu.Lock()
+			if rc.entries[key] == entry {
+				delete(rc.entries, key)
+			}
+			rc.mu.Unlock()
+			continue
+		}
+
+		entry = &responseCacheEntry{done: make(chan struct{})}
+		rc.entries[key] = entry
+		rc.mu.Unlock()
+
+		entry.body, entry.err = fetch()
+		entry.expires = time.Now().Add(rc.ttl)
+
+		if entry.err != nil {
+			rc.mu.Lock()
+			if rc.entries[key] == entry {
+				delete(rc.entries, key)
+			}
+			rc.mu.Unlock()
+		}
+		close(entry.done)
+
+		return entry.body, entry.err
+	}
+}
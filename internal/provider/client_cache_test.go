@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetCatalogFetchesOnce(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		_, _ = w.Write([]byte(`[{"id":"plugin-1","displayName":"Sample Data"}]`))
+	})
+	client.catalogCache = responseCache{ttl: time.Minute}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetLatestDataSources(context.Background(), "Sample Data", nil); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Fatalf("expected the plugin catalog to be fetched once, got %d", calls.Load())
+	}
+}
+
+func TestGetCatalogDoesNotCacheErrors(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	})
+	client.catalogCache = responseCache{ttl: time.Minute}
+
+	if _, err := client.getCatalog(context.Background(), "/api/alerting/channeltypes"); err == nil {
+		t.Fatal("expected the first request to fail")
+	}
+	if _, err := client.getCatalog(context.Background(), "/api/alerting/channeltypes"); err != nil {
+		t.Fatalf("expected the failed response not to be cached: %v", err)
+	}
+}
+
+func TestGetDataStreamsIsNotCached(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+		_, _ = w.Write([]byte(`[{"id":"datastream-1","definition":{"name":"health"}}]`))
+	})
+	client.catalogCache = responseCache{ttl: time.Minute}
+
+	if _, err := client.GetDataStreams(context.Background(), "plugin-1", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// A data source created in the meantime adds data streams to the plugin
+	dataStreams, err := client.GetDataStreams(context.Background(), "plugin-1", "health")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(dataStreams) != 1 || calls.Load() != 2 {
+		t.Fatalf("expected the data streams to be fetched again, got %d streams after %d requests", len(dataStreams), calls.Load())
+	}
+}
@@ -1,12 +1,16 @@
 package provider
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
-func (c *SquaredUpClient) CreateDashboard(displayName string, workspaceId string, timeframe string, dashboardContent string) (*Dashboard, error) {
+func (c *SquaredUpClient) CreateDashboard(ctx context.Context, displayName string, workspaceId string, timeframe string, dashboardContent string) (*Dashboard, error) {
 
 	DashboardPayload := map[string]interface{}{
 		"displayName": displayName,
@@ -21,7 +25,7 @@ func (c *SquaredUpClient) CreateDashboard(displayName string, workspaceId string
 
 	rb = []byte(strings.Replace(string(rb), "}", ",\"content\":"+dashboardContent+"}", 1))
 
-	req, err := http.NewRequest("POST", c.baseURL+"/api/dashboards", strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/api/dashboards", strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
@@ -40,8 +44,8 @@ func (c *SquaredUpClient) CreateDashboard(displayName string, workspaceId string
 	return &newDashboard, nil
 }
 
-func (c *SquaredUpClient) GetDashboard(dashboardId string) (*Dashboard, error) {
-	req, err := http.NewRequest("GET", c.baseURL+"/api/dashboards/"+dashboardId, nil)
+func (c *SquaredUpClient) GetDashboard(ctx context.Context, dashboardId string) (*Dashboard, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/api/dashboards/"+dashboardId, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -60,7 +64,41 @@ func (c *SquaredUpClient) GetDashboard(dashboardId string) (*Dashboard, error) {
 	return &newDashboard, nil
 }
 
-func (c *SquaredUpClient) UpdateDashboard(dashboardId string, displayName string, timeframe string, dashboardContent string) (*Dashboard, error) {
+func (c *SquaredUpClient) UpdateDashboard(ctx context.Context, dashboardId string, displayName string, timeframe string, dashboardContent string) (*Dashboard, error) {
+	unlock, err := c.lockDashboard(ctx, dashboardId)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	return c.updateDashboard(ctx, dashboardId, displayName, timeframe, dashboardContent)
+}
+
+// ModifyDashboard reads a dashboard, lets modify change it and writes the
+// result back, without other changes to the same dashboard interleaving.
+// modify may change the display name and timeframe, and returns the new
+// content.
+func (c *SquaredUpClient) ModifyDashboard(ctx context.Context, dashboardId string, modify func(dashboard *Dashboard) (string, error)) (*Dashboard, error) {
+	unlock, err := c.lockDashboard(ctx, dashboardId)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	dashboard, err := c.GetDashboard(ctx, dashboardId)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := modify(dashboard)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.updateDashboard(ctx, dashboardId, dashboard.DisplayName, dashboard.Timeframe, content)
+}
+
+func (c *SquaredUpClient) updateDashboard(ctx context.Context, dashboardId string, displayName string, timeframe string, dashboardContent string) (*Dashboard, error) {
 	DashboardPayload := map[string]interface{}{
 		"displayName": displayName,
 		"timeframe":   timeframe,
@@ -73,7 +111,7 @@ func (c *SquaredUpClient) UpdateDashboard(dashboardId string, displayName string
 
 	rb = []byte(strings.Replace(string(rb), "}", ",\"content\":"+dashboardContent+"}", 1))
 
-	req, err := http.NewRequest("PUT", c.baseURL+"/api/dashboards/"+dashboardId, strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", c.baseURL+"/api/dashboards/"+dashboardId, strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
@@ -92,8 +130,14 @@ func (c *SquaredUpClient) UpdateDashboard(dashboardId string, displayName string
 	return &newDashboard, nil
 }
 
-func (c *SquaredUpClient) DeleteDashboard(dashboardId string) error {
-	req, err := http.NewRequest("DELETE", c.baseURL+"/api/dashboards/"+dashboardId, nil)
+func (c *SquaredUpClient) DeleteDashboard(ctx context.Context, dashboardId string) error {
+	unlock, err := c.lockDashboard(ctx, dashboardId)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.baseURL+"/api/dashboards/"+dashboardId, nil)
 	if err != nil {
 		return err
 	}
@@ -105,3 +149,21 @@ func (c *SquaredUpClient) DeleteDashboard(dashboardId string) error {
 
 	return nil
 }
+
+// lockDashboard waits until no other change to dashboardId is in flight.
+// Dashboard tiles are merged into the content of their dashboard, so the
+// read and write of the content must not interleave with other changes.
+func (c *SquaredUpClient) lockDashboard(ctx context.Context, dashboardId string) (func(), error) {
+	start := time.Now()
+	unlock, err := c.dashboardLocks.Lock(ctx, dashboardId)
+	if err != nil {
+		return nil, err
+	}
+
+	tflog.Debug(ctx, "Acquired SquaredUp dashboard lock", map[string]interface{}{
+		"dashboard_id": dashboardId,
+		"wait_ms":      time.Since(start).Milliseconds(),
+	})
+
+	return unlock, nil
+}
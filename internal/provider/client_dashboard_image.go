@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"strconv"
@@ -8,11 +9,11 @@ import (
 	"time"
 )
 
-func (c *SquaredUpClient) GetDashboardImage(spaceId, dashId, tileId string) (*DashboardImage, error) {
+func (c *SquaredUpClient) GetDashboardImage(ctx context.Context, spaceId, dashId, tileId string) (*DashboardImage, error) {
 	currentTimeInMillis := time.Now().UnixMilli()
 	currentTimeStr := strconv.FormatInt(currentTimeInMillis, 10)
 	url := c.baseURL + "/api/workspaces/" + spaceId + "/dashboards/" + dashId + "/images/" + tileId + "?uploaded=" + currentTimeStr
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -31,14 +32,14 @@ func (c *SquaredUpClient) GetDashboardImage(spaceId, dashId, tileId string) (*Da
 	return &dashboardImage, nil
 }
 
-func (c *SquaredUpClient) UploadDashboardImage(spaceId, dashId, tileId string, dashboardImage *DashboardImage) error {
+func (c *SquaredUpClient) UploadDashboardImage(ctx context.Context, spaceId, dashId, tileId string, dashboardImage *DashboardImage) error {
 	url := c.baseURL + "/api/workspaces/" + spaceId + "/dashboards/" + dashId + "/images/" + tileId
 	rb, err := json.Marshal(dashboardImage)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("PUT", url, strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", url, strings.NewReader(string(rb)))
 	if err != nil {
 		return err
 	}
@@ -51,9 +52,9 @@ func (c *SquaredUpClient) UploadDashboardImage(spaceId, dashId, tileId string, d
 	return nil
 }
 
-func (c *SquaredUpClient) DeleteDashboardImage(spaceId, dashId, tileId string) error {
+func (c *SquaredUpClient) DeleteDashboardImage(ctx context.Context, spaceId, dashId, tileId string) error {
 	url := c.baseURL + "/api/workspaces/" + spaceId + "/dashboards/" + dashId + "/images/" + tileId
-	req, err := http.NewRequest("DELETE", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
 	if err != nil {
 		return err
 	}
@@ -1,18 +1,19 @@
 package provider
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"strings"
 )
 
-func (c *SquaredUpClient) CreateSharedDashboard(dashboardShare DashboardShare) (*DashboardShare, error) {
+func (c *SquaredUpClient) CreateSharedDashboard(ctx context.Context, dashboardShare DashboardShare) (*DashboardShare, error) {
 	rb, err := json.Marshal(dashboardShare)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", c.baseURL+"/api/openaccess/shares", strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/api/openaccess/shares", strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
@@ -31,8 +32,8 @@ func (c *SquaredUpClient) CreateSharedDashboard(dashboardShare DashboardShare) (
 	return &sharedDashboard, nil
 }
 
-func (c *SquaredUpClient) GetSharedDashboard(sharedDashboardId string) (*DashboardShare, error) {
-	req, err := http.NewRequest("GET", c.baseURL+"/api/openaccess/shares/"+sharedDashboardId, nil)
+func (c *SquaredUpClient) GetSharedDashboard(ctx context.Context, sharedDashboardId string) (*DashboardShare, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/api/openaccess/shares/"+sharedDashboardId, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -51,13 +52,13 @@ func (c *SquaredUpClient) GetSharedDashboard(sharedDashboardId string) (*Dashboa
 	return &sharedDashboard, nil
 }
 
-func (c *SquaredUpClient) UpdateSharedDashboard(sharedDashboardId string, dashboardShare DashboardShare) error {
+func (c *SquaredUpClient) UpdateSharedDashboard(ctx context.Context, sharedDashboardId string, dashboardShare DashboardShare) error {
 	rb, err := json.Marshal(dashboardShare)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("PUT", c.baseURL+"/api/openaccess/shares/"+sharedDashboardId, strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", c.baseURL+"/api/openaccess/shares/"+sharedDashboardId, strings.NewReader(string(rb)))
 	if err != nil {
 		return err
 	}
@@ -70,8 +71,8 @@ func (c *SquaredUpClient) UpdateSharedDashboard(sharedDashboardId string, dashbo
 	return nil
 }
 
-func (c *SquaredUpClient) DeleteSharedDashboard(sharedDashboardId string) error {
-	req, err := http.NewRequest("DELETE", c.baseURL+"/api/openaccess/shares/"+sharedDashboardId, nil)
+func (c *SquaredUpClient) DeleteSharedDashboard(ctx context.Context, sharedDashboardId string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.baseURL+"/api/openaccess/shares/"+sharedDashboardId, nil)
 	if err != nil {
 		return err
 	}
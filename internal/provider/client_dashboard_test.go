@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestModifyDashboardKeepsDisplayNameAndTimeframe(t *testing.T) {
+	var updated map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"id":"dash-1","displayName":"Shared","timeframe":"last24hours","workspaceId":"space-1","content":{"contents":[]}}`))
+		case http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(body, &updated); err != nil {
+				t.Errorf("request body is not valid JSON: %v", err)
+			}
+			_, _ = w.Write(body)
+		}
+	})
+
+	_, err := client.ModifyDashboard(context.Background(), "dash-1", func(dashboard *Dashboard) (string, error) {
+		if dashboard.WorkspaceID != "space-1" {
+			t.Errorf("expected the current dashboard, got %+v", dashboard)
+		}
+		return `{"contents":[{"i":"tile-1"}]}`, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if updated["displayName"] != "Shared" || updated["timeframe"] != "last24hours" {
+		t.Errorf("expected display name and timeframe to be kept, got %v", updated)
+	}
+	if tiles := updated["content"].(map[string]interface{})["contents"].([]interface{}); len(tiles) != 1 {
+		t.Errorf("expected the modified content to be sent, got %v", updated["content"])
+	}
+}
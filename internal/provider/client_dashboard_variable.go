@@ -1,18 +1,19 @@
 package provider
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"strings"
 )
 
-func (c *SquaredUpClient) CreateDashboardVariable(variable DashboardVariable, workspaceId string) (*DashboardVariableRead, error) {
+func (c *SquaredUpClient) CreateDashboardVariable(ctx context.Context, variable DashboardVariable, workspaceId string) (*DashboardVariableRead, error) {
 	rb, err := json.Marshal(variable)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", c.baseURL+"/api/workspaces/"+workspaceId+"/variables", strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/api/workspaces/"+workspaceId+"/variables", strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
@@ -31,8 +32,8 @@ func (c *SquaredUpClient) CreateDashboardVariable(variable DashboardVariable, wo
 	return &variableRead, nil
 }
 
-func (c *SquaredUpClient) GetDashboardVariable(variableId string) (*DashboardVariableRead, error) {
-	req, err := http.NewRequest("GET", c.baseURL+"/api/variables/"+variableId, nil)
+func (c *SquaredUpClient) GetDashboardVariable(ctx context.Context, variableId string) (*DashboardVariableRead, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/api/variables/"+variableId, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -51,13 +52,44 @@ func (c *SquaredUpClient) GetDashboardVariable(variableId string) (*DashboardVar
 	return &variable, nil
 }
 
-func (c *SquaredUpClient) UpdateDashboardVariable(variableId string, variable DashboardVariable) (*DashboardVariableRead, error) {
-	rb, err := json.Marshal(variable)
+// ListDashboardVariables returns every dashboard variable of a workspace.
+func (c *SquaredUpClient) ListDashboardVariables(ctx context.Context, workspaceId string) ([]DashboardVariableRead, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/api/workspaces/"+workspaceId+"/variables", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	variables := []DashboardVariableRead{}
+	err = json.Unmarshal(body, &variables)
+	if err != nil {
+		return nil, err
+	}
+
+	return variables, nil
+}
+
+func (c *SquaredUpClient) UpdateDashboardVariable(ctx context.Context, variableId string, variable DashboardVariable) (*DashboardVariableRead, error) {
+	return c.putDashboardVariable(ctx, variableId, variable)
+}
+
+// DetachDashboardVariable updates a variable and unbinds it from its
+// dashboard.
+func (c *SquaredUpClient) DetachDashboardVariable(ctx context.Context, variableId string, variable DashboardVariable) (*DashboardVariableRead, error) {
+	return c.putDashboardVariable(ctx, variableId, DashboardVariableDetach{DashboardVariable: variable})
+}
+
+func (c *SquaredUpClient) putDashboardVariable(ctx context.Context, variableId string, payload interface{}) (*DashboardVariableRead, error) {
+	rb, err := json.Marshal(payload)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("PUT", c.baseURL+"/api/variables/"+variableId, strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", c.baseURL+"/api/variables/"+variableId, strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
@@ -76,8 +108,8 @@ func (c *SquaredUpClient) UpdateDashboardVariable(variableId string, variable Da
 	return &variableRead, nil
 }
 
-func (c *SquaredUpClient) DeleteDashboardVariable(variableId string) error {
-	req, err := http.NewRequest("DELETE", c.baseURL+"/api/variables/"+variableId, nil)
+func (c *SquaredUpClient) DeleteDashboardVariable(ctx context.Context, variableId string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.baseURL+"/api/variables/"+variableId, nil)
 	if err != nil {
 		return err
 	}
@@ -1,19 +1,15 @@
 package provider
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
 )
 
-func (c *SquaredUpClient) GetLatestDataSources(filterDisplayName string, onPrem *bool) ([]LatestDataSource, error) {
-	req, err := http.NewRequest("GET", c.baseURL+"/api/plugins/latest", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	body, err := c.doRequest(req)
+func (c *SquaredUpClient) GetLatestDataSources(ctx context.Context, filterDisplayName string, onPrem *bool) ([]LatestDataSource, error) {
+	body, err := c.getCatalog(ctx, "/api/plugins/latest")
 	if err != nil {
 		return nil, err
 	}
@@ -45,8 +41,8 @@ func (c *SquaredUpClient) GetLatestDataSources(filterDisplayName string, onPrem
 
 }
 
-func (c *SquaredUpClient) GenerateDataSourcePayload(displayName string, name string, onPrem *bool, pluginConfig map[string]interface{}, agentGroupId string) (map[string]interface{}, error) {
-	plugins, err := c.GetLatestDataSources(name, onPrem)
+func (c *SquaredUpClient) GenerateDataSourcePayload(ctx context.Context, displayName string, name string, onPrem *bool, pluginConfig map[string]interface{}, agentGroupId string) (map[string]interface{}, error) {
+	plugins, err := c.GetLatestDataSources(ctx, name, onPrem)
 	if err != nil {
 		return nil, err
 	}
@@ -82,8 +78,8 @@ func (c *SquaredUpClient) GenerateDataSourcePayload(displayName string, name str
 	return DataSourcePayload, nil
 }
 
-func (c *SquaredUpClient) AddDataSource(displayName string, name string, onPrem *bool, pluginConfig map[string]interface{}, agentGroupId string) (*DataSource, error) {
-	DataSourcePayload, err := c.GenerateDataSourcePayload(displayName, name, onPrem, pluginConfig, agentGroupId)
+func (c *SquaredUpClient) AddDataSource(ctx context.Context, displayName string, name string, onPrem *bool, pluginConfig map[string]interface{}, agentGroupId string) (*DataSource, error) {
+	DataSourcePayload, err := c.GenerateDataSourcePayload(ctx, displayName, name, onPrem, pluginConfig, agentGroupId)
 	if err != nil {
 		return nil, err
 	}
@@ -93,7 +89,7 @@ func (c *SquaredUpClient) AddDataSource(displayName string, name string, onPrem
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", c.baseURL+"/api/datasources", strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/api/datasources", strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
@@ -112,8 +108,8 @@ func (c *SquaredUpClient) AddDataSource(displayName string, name string, onPrem
 	return &newDataSource, nil
 }
 
-func (c *SquaredUpClient) GetDataSource(dataSourceId string) (*DataSource, error) {
-	req, err := http.NewRequest("GET", c.baseURL+"/api/datasources/"+dataSourceId, nil)
+func (c *SquaredUpClient) GetDataSource(ctx context.Context, dataSourceId string) (*DataSource, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/api/datasources/"+dataSourceId, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -132,8 +128,8 @@ func (c *SquaredUpClient) GetDataSource(dataSourceId string) (*DataSource, error
 	return &dataSource, nil
 }
 
-func (c *SquaredUpClient) UpdateDataSource(dataSourceId string, displayName string, name string, onPrem *bool, pluginConfig map[string]interface{}, agentGroupId string) error {
-	DataSourcePayload, err := c.GenerateDataSourcePayload(displayName, name, onPrem, pluginConfig, agentGroupId)
+func (c *SquaredUpClient) UpdateDataSource(ctx context.Context, dataSourceId string, displayName string, name string, onPrem *bool, pluginConfig map[string]interface{}, agentGroupId string) error {
+	DataSourcePayload, err := c.GenerateDataSourcePayload(ctx, displayName, name, onPrem, pluginConfig, agentGroupId)
 	if err != nil {
 		return err
 	}
@@ -143,7 +139,7 @@ func (c *SquaredUpClient) UpdateDataSource(dataSourceId string, displayName stri
 		return err
 	}
 
-	req, err := http.NewRequest("PUT", c.baseURL+"/api/datasources/"+dataSourceId, strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", c.baseURL+"/api/datasources/"+dataSourceId, strings.NewReader(string(rb)))
 	if err != nil {
 		return err
 	}
@@ -156,8 +152,8 @@ func (c *SquaredUpClient) UpdateDataSource(dataSourceId string, displayName stri
 	return nil
 }
 
-func (c *SquaredUpClient) DeleteDataSource(dataSourceId string) error {
-	req, err := http.NewRequest("DELETE", c.baseURL+"/api/datasources/"+dataSourceId, nil)
+func (c *SquaredUpClient) DeleteDataSource(ctx context.Context, dataSourceId string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.baseURL+"/api/datasources/"+dataSourceId, nil)
 	if err != nil {
 		return err
 	}
@@ -1,13 +1,16 @@
 package provider
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 )
 
-func (c *SquaredUpClient) GetDataStreams(dataSourceId string, DataStreamDefinitionName string) ([]DataSourceDataStreams, error) {
-	req, err := http.NewRequest("GET", c.baseURL+"/api/datastreams/plugin/"+dataSourceId, nil)
+func (c *SquaredUpClient) GetDataStreams(ctx context.Context, dataSourceId string, DataStreamDefinitionName string) ([]DataSourceDataStreams, error) {
+	// Not cached, the data streams of a plugin change when a data source
+	// is added or updated during the same run
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/api/datastreams/plugin/"+dataSourceId, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// APIError is returned by the client when the SquaredUp API responds with an
+// unexpected status code. Use errors.As to inspect the status code.
+type APIError struct {
+	StatusCode int
+	Method     string
+	Path       string
+	RequestID  string
+	Message    string
+	Body       []byte
+}
+
+func (e *APIError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "status: %d", e.StatusCode)
+	if e.Method != "" {
+		fmt.Fprintf(&sb, ", request: %s %s", e.Method, e.Path)
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&sb, ", request id: %s", e.RequestID)
+	}
+	if e.Message != "" {
+		fmt.Fprintf(&sb, ", message: %s", e.Message)
+	} else {
+		fmt.Fprintf(&sb, ", body: %s", e.Body)
+	}
+	return sb.String()
+}
+
+func newAPIError(res *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: res.StatusCode,
+		Body:       body,
+	}
+
+	if res.Request != nil {
+		apiErr.Method = res.Request.Method
+		apiErr.Path = res.Request.URL.Path
+	}
+
+	for _, header := range []string{"X-Request-Id", "X-Amzn-Requestid", "X-Amz-Apigw-Id"} {
+		if id := res.Header.Get(header); id != "" {
+			apiErr.RequestID = id
+			break
+		}
+	}
+
+	var decoded struct {
+		Message string `json:"message"`
+		Error   string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &decoded); err == nil {
+		apiErr.Message = decoded.Message
+		if apiErr.Message == "" {
+			apiErr.Message = decoded.Error
+		}
+	}
+
+	return apiErr
+}
+
+// isNotFound reports whether err is an APIError for a 404 response.
+func isNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	defaultGraphQueryTimeout      = 5 * time.Minute
+	defaultGraphQueryPollInterval = 30 * time.Second
+)
+
+// graphQueryWait controls how long a graph query is repeated while it returns
+// no results, for example while a new data source is still being indexed.
+type graphQueryWait struct {
+	Enabled      bool
+	Timeout      time.Duration
+	PollInterval time.Duration
+}
+
+// gremlinQuery builds a Gremlin traversal in which every caller supplied value
+// is passed as a binding rather than interpolated into the query text.
+type gremlinQuery struct {
+	traversal strings.Builder
+	bindings  map[string]interface{}
+	prefix    string
+}
+
+// newGremlinQuery starts a query that is run on its own, so its bindings are
+// simply numbered.
+func newGremlinQuery() *gremlinQuery {
+	return newPrefixedGremlinQuery("binding")
+}
+
+// newPrefixedGremlinQuery starts a query whose bindings are named after
+// prefix. Queries that are stored, such as scopes and tile scopes, may be
+// combined with other bindings by SquaredUp, so each needs its own prefix.
+func newPrefixedGremlinQuery(prefix string) *gremlinQuery {
+	q := &gremlinQuery{bindings: make(map[string]interface{}), prefix: prefix}
+	q.traversal.WriteString("g.V()")
+	return q
+}
+
+// Step appends a traversal step. Each %s placeholder in step is replaced by
+// the name of a binding that holds the matching value, so step itself must be
+// a constant.
+func (q *gremlinQuery) Step(step string, values ...interface{}) *gremlinQuery {
+	names := make([]interface{}, len(values))
+	for i, value := range values {
+		name := fmt.Sprintf("%s_%d", q.prefix, len(q.bindings))
+		q.bindings[name] = value
+		names[i] = name
+	}
+	q.traversal.WriteString(fmt.Sprintf(step, names...))
+	return q
+}
+
+func (q *gremlinQuery) String() string {
+	return q.traversal.String()
+}
+
+func (q *gremlinQuery) Bindings() map[string]interface{} {
+	return q.bindings
+}
+
+// runGraphQuery sends a Gremlin query with its bindings to the graph API.
+func (c *SquaredUpClient) runGraphQuery(ctx context.Context, query string, bindings map[string]interface{}) ([]byte, error) {
+	rb := map[string]interface{}{
+		"gremlinQuery": query,
+	}
+	if len(bindings) != 0 {
+		rb["bindings"] = bindings
+	}
+
+	reqBody, err := json.Marshal(rb)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/api/graph/query", strings.NewReader(string(reqBody)))
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doRequest(req)
+}
+
+// pollGraphQuery runs a Gremlin query until handle reports that results were
+// found or wait gives up. It returns whether results were found and how many
+// times the query was sent. The error of the last attempt is only returned
+// when no attempt succeeded.
+func (c *SquaredUpClient) pollGraphQuery(ctx context.Context, query string, bindings map[string]interface{}, wait graphQueryWait, handle func(body []byte) (bool, error)) (bool, int, error) {
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		body, err := c.runGraphQuery(ctx, query, bindings)
+		if err == nil {
+			found, err := handle(body)
+			if err != nil || found {
+				return found, attempt, err
+			}
+		}
+
+		if !wait.Enabled || time.Since(start)+wait.PollInterval > wait.Timeout {
+			return false, attempt, err
+		}
+
+		fields := map[string]interface{}{
+			"attempt":       attempt,
+			"elapsed_ms":    time.Since(start).Milliseconds(),
+			"timeout_ms":    wait.Timeout.Milliseconds(),
+			"poll_interval": wait.PollInterval.String(),
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+		}
+		tflog.Info(ctx, "Waiting for SquaredUp graph query results", fields)
+
+		if err := sleepWithContext(ctx, wait.PollInterval); err != nil {
+			return false, attempt, err
+		}
+	}
+}
+
+// GraphQuery runs a caller supplied Gremlin query and returns its raw results.
+// When wait is enabled the query is repeated until it returns results.
+func (c *SquaredUpClient) GraphQuery(ctx context.Context, query string, bindings map[string]interface{}, wait graphQueryWait) ([]interface{}, error) {
+	var results []interface{}
+	found, attempts, err := c.pollGraphQuery(ctx, query, bindings, wait, func(body []byte) (bool, error) {
+		var response struct {
+			GremlinQueryResults []interface{} `json:"gremlinQueryResults"`
+		}
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+		if err := decoder.Decode(&response); err != nil {
+			return false, err
+		}
+
+		results = response.GremlinQueryResults
+		return len(results) != 0, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !found && wait.Enabled {
+		return nil, fmt.Errorf("error: graph query returned no results. attempted to run it %d times", attempts)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestGremlinQueryBindsValues(t *testing.T) {
+	nodeName := "O'Brien's server').drop().V('"
+	query := newGremlinQuery().
+		Step(".has('__configId', %s)", "config-1").
+		Step(".has('name', %s)", nodeName).
+		Step(".valueMap(true)")
+
+	if strings.Contains(query.String(), "O'Brien") {
+		t.Fatalf("user input was interpolated into the query: %s", query.String())
+	}
+	if query.String() != "g.V().has('__configId', binding_0).has('name', binding_1).valueMap(true)" {
+		t.Fatalf("unexpected query: %s", query.String())
+	}
+	if query.Bindings()["binding_1"] != nodeName {
+		t.Fatalf("unexpected bindings: %v", query.Bindings())
+	}
+}
+
+func TestPrefixedGremlinQueriesCanBeCombined(t *testing.T) {
+	scope, err := buildFixedScope(SquaredUpScope{
+		DisplayName: types.StringValue("Hosts"),
+		NodeIds:     []types.String{types.StringValue("node-1")},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tile := testDashboardTile("Cost", 0)
+	tile.ID = types.StringValue("tile-1")
+	tile.NodeIDs = types.ListValueMust(types.StringType, []attr.Value{types.StringValue("node-2")})
+	compiled, diags := compileDashboardTile(context.Background(), "space-1", tile)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	combined := map[string]interface{}{}
+	for _, bindings := range []map[string]interface{}{scope.Scope.Bindings, compiled.Config.Scope.Bindings} {
+		for name, value := range bindings {
+			if _, exists := combined[name]; exists {
+				t.Fatalf("binding %s is used by both queries", name)
+			}
+			combined[name] = value
+		}
+	}
+	if len(combined) != 2 {
+		t.Fatalf("expected two bindings, got %v", combined)
+	}
+}
+
+func TestGraphQueryReturnsRawResults(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			GremlinQuery string                 `json:"gremlinQuery"`
+			Bindings     map[string]interface{} `json:"bindings"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		if body.GremlinQuery != "g.V().hasId(within(ids)).count()" {
+			t.Errorf("unexpected query: %s", body.GremlinQuery)
+		}
+		if ids, ok := body.Bindings["ids"].([]interface{}); !ok || len(ids) != 2 {
+			t.Errorf("unexpected bindings: %v", body.Bindings)
+		}
+		_, _ = w.Write([]byte(`{"gremlinQueryResults":[12345678901234567890]}`))
+	})
+
+	results, err := client.GraphQuery(context.Background(), "g.V().hasId(within(ids)).count()", map[string]interface{}{"ids": []string{"node-1", "node-2"}}, graphQueryWait{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 1 || results[0] != json.Number("12345678901234567890") {
+		t.Fatalf("unexpected results: %v", results)
+	}
+}
+
+func TestGraphQueryFailsWhenWaitingTimesOut(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"gremlinQueryResults":[]}`))
+	})
+
+	wait := graphQueryWait{Enabled: true, Timeout: 5 * time.Millisecond, PollInterval: time.Millisecond}
+	if _, err := client.GraphQuery(context.Background(), "g.V().limit(1)", nil, wait); err == nil {
+		t.Fatal("expected an error when no results appear before the timeout")
+	}
+
+	results, err := client.GraphQuery(context.Background(), "g.V().limit(1)", nil, graphQueryWait{})
+	if err != nil || len(results) != 0 {
+		t.Fatalf("expected no results and no error without waiting, got %v, %v", results, err)
+	}
+}
@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// requestLimiter caps the number of in-flight requests and the rate at which
+// new requests start. A zero value for either limit disables it.
+type requestLimiter struct {
+	slots  chan struct{}
+	bucket *tokenBucket
+}
+
+func newRequestLimiter(maxConcurrentRequests int, requestsPerSecond int) *requestLimiter {
+	limiter := &requestLimiter{}
+
+	if maxConcurrentRequests > 0 {
+		limiter.slots = make(chan struct{}, maxConcurrentRequests)
+	}
+
+	if requestsPerSecond > 0 {
+		limiter.bucket = newTokenBucket(float64(requestsPerSecond), requestsPerSecond)
+	}
+
+	return limiter
+}
+
+// Acquire blocks until a request may be sent. On success the returned
+// function must be called once the request has completed.
+func (l *requestLimiter) Acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	if l.bucket != nil {
+		if err := l.bucket.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if l.slots == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case l.slots <- struct{}{}:
+		return func() { <-l.slots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+type tokenBucket struct {
+	mu       sync.Mutex
+	rate     float64
+	capacity float64
+	tokens   float64
+	last     time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	return &tokenBucket{
+		rate:     rate,
+		capacity: float64(burst),
+		tokens:   float64(burst),
+		last:     time.Now(),
+	}
+}
+
+// Wait takes a token from the bucket, sleeping until one is available.
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.capacity {
+			b.tokens = b.capacity
+		}
+		b.last = now
+
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+
+		wait := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		b.mu.Unlock()
+
+		if err := sleepWithContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRequestLimiterCapsConcurrency(t *testing.T) {
+	limiter := newRequestLimiter(1, 0)
+
+	release, err := limiter.Acquire(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := limiter.Acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the second request to wait, got: %v", err)
+	}
+
+	release()
+	release, err = limiter.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("expected a free slot after release: %v", err)
+	}
+	release()
+}
+
+func TestRequestLimiterRateLimits(t *testing.T) {
+	limiter := newRequestLimiter(0, 20)
+
+	start := time.Now()
+	for i := 0; i < 25; i++ {
+		release, err := limiter.Acquire(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		release()
+	}
+
+	// The first 20 requests use the initial burst, the remaining 5 wait for
+	// tokens at 20 per second.
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Fatalf("expected rate limiting to slow requests down, took %s", elapsed)
+	}
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+	"sync"
+)
+
+// keyedMutex serializes work per key, for example per workspace ID, while
+// letting work on different keys run in parallel. Waiting for a key can be
+// abandoned by cancelling the context.
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*keyedMutexEntry
+}
+
+type keyedMutexEntry struct {
+	sem  chan struct{}
+	refs int
+}
+
+// Lock blocks until key is free or ctx is done. On success the returned
+// function must be called to release the key.
+func (m *keyedMutex) Lock(ctx context.Context, key string) (func(), error) {
+	m.mu.Lock()
+	if m.locks == nil {
+		m.locks = make(map[string]*keyedMutexEntry)
+	}
+	entry, ok := m.locks[key]
+	if !ok {
+		entry = &keyedMutexEntry{sem: make(chan struct{}, 1)}
+		m.locks[key] = entry
+	}
+	entry.refs++
+	m.mu.Unlock()
+
+	select {
+	case entry.sem <- struct{}{}:
+		return func() {
+			<-entry.sem
+			m.release(key, entry)
+		}, nil
+	case <-ctx.Done():
+		m.release(key, entry)
+		return nil, ctx.Err()
+	}
+}
+
+func (m *keyedMutex) release(key string, entry *keyedMutexEntry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry.refs--
+	if entry.refs == 0 {
+		delete(m.locks, key)
+	}
+}
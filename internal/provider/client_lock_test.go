@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestKeyedMutexSerializesSameKey(t *testing.T) {
+	var m keyedMutex
+	var active, maxActive atomic.Int32
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock, err := m.Lock(context.Background(), "workspace-1")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			defer unlock()
+
+			n := active.Add(1)
+			if n > maxActive.Load() {
+				maxActive.Store(n)
+			}
+			time.Sleep(time.Millisecond)
+			active.Add(-1)
+		}()
+	}
+	wg.Wait()
+
+	if maxActive.Load() != 1 {
+		t.Fatalf("expected at most one holder, got %d", maxActive.Load())
+	}
+	if len(m.locks) != 0 {
+		t.Fatalf("expected released keys to be removed, got %d", len(m.locks))
+	}
+}
+
+func TestKeyedMutexHonoursContext(t *testing.T) {
+	var m keyedMutex
+	unlock, err := m.Lock(context.Background(), "workspace-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := m.Lock(ctx, "workspace-1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got: %v", err)
+	}
+
+	otherUnlock, err := m.Lock(context.Background(), "workspace-2")
+	if err != nil {
+		t.Fatalf("different keys must not block each other: %v", err)
+	}
+	otherUnlock()
+}
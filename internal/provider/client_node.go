@@ -1,78 +1,170 @@
 package provider
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
+	"regexp"
+	"sort"
 	"strings"
-	"time"
 )
 
-const maxRetries = 10
-const retryDelaySeconds = 30
+// nodeFilter selects nodes of a data source. Name, SourceID, Type and
+// Properties are evaluated by the graph API, NamePrefix and PropertyRegex are
+// applied to the returned nodes.
+type nodeFilter struct {
+	DataSourceID  string
+	Name          string
+	SourceID      string
+	NamePrefix    string
+	Type          string
+	Properties    map[string]string
+	PropertyRegex map[string]*regexp.Regexp
+	Limit         int
+}
 
-func (c *SquaredUpClient) GetNodes(dataSourceId string, nodeName string, nodeSourceId string, allowNull bool) ([]GremlinQueryResult, error) {
-	var gremlinQueryResults []GremlinQueryResult
-	var errMessage string
-	for attempt := 1; attempt <= maxRetries; attempt++ {
-		errMessage = fmt.Sprintf("no nodes found with name: %s in data source: %s. attempted to search for it %d times", nodeName, dataSourceId, attempt)
-		rb := map[string]interface{}{
-			"gremlinQuery": "g.V().has('__configId', '" + dataSourceId + "').has('name', '" + nodeName + "').hasNot('__canonicalType').valueMap(true)",
-		}
+func (f nodeFilter) filtersLocally() bool {
+	return f.NamePrefix != "" || len(f.PropertyRegex) != 0
+}
 
-		if nodeName == "" {
-			rb = map[string]interface{}{
-				"gremlinQuery": "g.V().has('__configId', '" + dataSourceId + "').hasNot('__canonicalType').valueMap(true)",
-			}
-			errMessage = fmt.Sprintf("failed to get nodes from data source: %s. attempted to search for it %d times", dataSourceId, attempt)
-		}
+func (f nodeFilter) matches(node GremlinQueryResult) bool {
+	if f.NamePrefix != "" && !strings.HasPrefix(firstValue(node.DisplayName), f.NamePrefix) {
+		return false
+	}
 
-		if nodeSourceId != "" {
-			rb = map[string]interface{}{
-				"gremlinQuery": "g.V().has('__configId', '" + dataSourceId + "').has('sourceId', '" + nodeSourceId + "').hasNot('__canonicalType').valueMap(true)",
-			}
-			errMessage = fmt.Sprintf("no nodes found with source id: %s in data source: %s. attempted to search for it %d times", nodeSourceId, dataSourceId, attempt)
+	for key, pattern := range f.PropertyRegex {
+		value, ok := node.Properties[key]
+		if !ok || !pattern.MatchString(propertyString(value)) {
+			return false
 		}
+	}
 
-		reqBody, err := json.Marshal(rb)
-		if err != nil {
-			return nil, err
-		}
+	return true
+}
 
-		req, err := http.NewRequest("POST", c.baseURL+"/api/graph/query", strings.NewReader(string(reqBody)))
-		if err != nil {
-			return nil, err
-		}
+func (f nodeFilter) String() string {
+	var parts []string
+	if f.SourceID != "" {
+		parts = append(parts, "source id: "+f.SourceID)
+	} else if f.Name != "" {
+		parts = append(parts, "name: "+f.Name)
+	}
+	if f.NamePrefix != "" {
+		parts = append(parts, "name prefix: "+f.NamePrefix)
+	}
+	if f.Type != "" {
+		parts = append(parts, "type: "+f.Type)
+	}
 
-		body, err := c.doRequest(req)
-		if err != nil {
-			if attempt < maxRetries {
-				time.Sleep(retryDelaySeconds * time.Second)
-				continue
-			}
-			return nil, err
+	keys := make([]string, 0, len(f.Properties)+len(f.PropertyRegex))
+	for key := range f.Properties {
+		keys = append(keys, key)
+	}
+	for key := range f.PropertyRegex {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if value, ok := f.Properties[key]; ok {
+			parts = append(parts, fmt.Sprintf("%s = %s", key, value))
+		}
+		if pattern, ok := f.PropertyRegex[key]; ok {
+			parts = append(parts, fmt.Sprintf("%s =~ %s", key, pattern.String()))
 		}
+	}
+
+	return strings.Join(parts, ", ")
+}
 
+func (c *SquaredUpClient) GetNodes(ctx context.Context, filter nodeFilter, allowNull bool, wait graphQueryWait) ([]GremlinQueryResult, error) {
+	query := newGremlinQuery().Step(".has('__configId', %s)", filter.DataSourceID)
+
+	if filter.SourceID != "" {
+		query.Step(".has('sourceId', %s)", filter.SourceID)
+	} else if filter.Name != "" {
+		query.Step(".has('name', %s)", filter.Name)
+	}
+
+	if filter.Type != "" {
+		query.Step(".has('type', %s)", filter.Type)
+	}
+
+	keys := make([]string, 0, len(filter.Properties))
+	for key := range filter.Properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		query.Step(".has(%s, %s)", key, filter.Properties[key])
+	}
+
+	query.Step(".hasNot('__canonicalType')")
+
+	// A server side limit would be applied before the local filters
+	if filter.Limit > 0 && !filter.filtersLocally() {
+		query.Step(".limit(%s)", filter.Limit)
+	}
+
+	query.Step(".valueMap(true)")
+
+	var gremlinQueryResults []GremlinQueryResult
+	found, attempts, err := c.pollGraphQuery(ctx, query.String(), query.Bindings(), wait, func(body []byte) (bool, error) {
 		var response SquaredupGremlinQuery
-		err = json.Unmarshal(body, &response)
+		err := json.Unmarshal(body, &response)
 		if err != nil {
-			return nil, err
+			return false, err
 		}
 
-		if len(response.GremlinQueryResults) == 0 {
-			if attempt < maxRetries {
-				time.Sleep(retryDelaySeconds * time.Second)
+		gremlinQueryResults = gremlinQueryResults[:0]
+		for _, node := range response.GremlinQueryResults {
+			if !filter.matches(node) {
 				continue
 			}
-			if !allowNull {
-				return nil, fmt.Errorf("error: %s", errMessage)
+			gremlinQueryResults = append(gremlinQueryResults, node)
+			if filter.Limit > 0 && len(gremlinQueryResults) == filter.Limit {
+				break
 			}
 		}
 
-		gremlinQueryResults = response.GremlinQueryResults
-		break
+		return len(gremlinQueryResults) != 0, nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
+	if !found && !allowNull {
+		if description := filter.String(); description != "" {
+			return nil, fmt.Errorf("error: no nodes found with %s in data source: %s. attempted to search for it %d times", description, filter.DataSourceID, attempts)
+		}
+		return nil, fmt.Errorf("error: failed to get nodes from data source: %s. attempted to search for it %d times", filter.DataSourceID, attempts)
 	}
 
 	return gremlinQueryResults, nil
 }
+
+// firstValue returns the first element of a valueMap property, or an empty
+// string when the property is missing.
+func firstValue(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// propertyString converts a valueMap property to a string. Single valued
+// properties are unwrapped, anything else is JSON encoded.
+func propertyString(value interface{}) string {
+	if values, ok := value.([]interface{}); ok && len(values) == 1 {
+		value = values[0]
+	}
+
+	if s, ok := value.(string); ok {
+		return s
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(encoded)
+}
@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+)
+
+// neighborTraversal describes a walk along the edges of the graph, starting
+// from a single node.
+type neighborTraversal struct {
+	NodeID     string
+	Direction  string
+	EdgeLabels []string
+	Depth      int
+	Limit      int
+}
+
+// neighborSteps maps a traversal direction to the Gremlin steps that select
+// the edges of a node and the node on the other side of them.
+var neighborSteps = map[string]struct{ edges, other string }{
+	"out":  {edges: "outE()", other: "inV()"},
+	"in":   {edges: "inE()", other: "outV()"},
+	"both": {edges: "bothE()", other: "otherV()"},
+}
+
+// GetNodeNeighbors returns the nodes reachable from traversal.NodeID and the
+// edges between all visited nodes, including the starting node.
+func (c *SquaredUpClient) GetNodeNeighbors(ctx context.Context, traversal neighborTraversal) ([]GremlinQueryResult, []GremlinEdge, error) {
+	steps, ok := neighborSteps[traversal.Direction]
+	if !ok {
+		steps = neighborSteps["both"]
+	}
+
+	query := newGremlinQuery().Step(".hasId(%s)", traversal.NodeID)
+	if len(traversal.EdgeLabels) != 0 {
+		query.Step(".repeat("+steps.edges+".hasLabel(within(%s))."+steps.other+")", traversal.EdgeLabels)
+	} else {
+		query.Step(".repeat(" + steps.edges + "." + steps.other + ")")
+	}
+	// Cycles can lead back to the starting node, which must not count
+	// towards the limit
+	query.Step(".emit().times(%s).not(hasId(%s)).dedup()", traversal.Depth, traversal.NodeID)
+	if traversal.Limit > 0 {
+		query.Step(".limit(%s)", traversal.Limit)
+	}
+	query.Step(".valueMap(true)")
+
+	body, err := c.runGraphQuery(ctx, query.String(), query.Bindings())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var response SquaredupGremlinQuery
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, nil, err
+	}
+
+	// The starting node is already excluded by the traversal, this only
+	// guards the edge query below
+	nodes := make([]GremlinQueryResult, 0, len(response.GremlinQueryResults))
+	nodeIDs := []string{traversal.NodeID}
+	for _, node := range response.GremlinQueryResults {
+		if node.ID == traversal.NodeID {
+			continue
+		}
+		nodes = append(nodes, node)
+		nodeIDs = append(nodeIDs, node.ID)
+	}
+
+	if len(nodes) == 0 {
+		return nodes, []GremlinEdge{}, nil
+	}
+
+	edgeQuery := newGremlinQuery().Step(".hasId(within(%s))", nodeIDs)
+	edgeQuery.Step("." + steps.edges)
+	if len(traversal.EdgeLabels) != 0 {
+		edgeQuery.Step(".hasLabel(within(%s))", traversal.EdgeLabels)
+	}
+	edgeQuery.Step(".where(otherV().hasId(within(%s))).dedup()", nodeIDs)
+	edgeQuery.Step(".project('id', 'label', 'outV', 'inV').by(id).by(label).by(outV().id()).by(inV().id())")
+
+	body, err = c.runGraphQuery(ctx, edgeQuery.String(), edgeQuery.Bindings())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var edgeResponse struct {
+		GremlinQueryResults []GremlinEdge `json:"gremlinQueryResults"`
+	}
+	if err := json.Unmarshal(body, &edgeResponse); err != nil {
+		return nil, nil, err
+	}
+
+	return nodes, edgeResponse.GremlinQueryResults, nil
+}
+
+// UnmarshalJSON decodes an edge from the projection in GetNodeNeighbors.
+// Depending on the graph, edge IDs are strings, numbers or objects, so
+// non-string IDs are kept as their JSON text.
+func (e *GremlinEdge) UnmarshalJSON(data []byte) error {
+	var edge struct {
+		ID         json.RawMessage `json:"id"`
+		Label      string          `json:"label"`
+		FromNodeID json.RawMessage `json:"outV"`
+		ToNodeID   json.RawMessage `json:"inV"`
+	}
+	if err := json.Unmarshal(data, &edge); err != nil {
+		return err
+	}
+
+	*e = GremlinEdge{
+		ID:         gremlinIDString(edge.ID),
+		Label:      edge.Label,
+		FromNodeID: gremlinIDString(edge.FromNodeID),
+		ToNodeID:   gremlinIDString(edge.ToNodeID),
+	}
+	return nil
+}
+
+// gremlinIDString formats a Gremlin element ID of any JSON type as a string.
+func gremlinIDString(id json.RawMessage) string {
+	var value string
+	if err := json.Unmarshal(id, &value); err == nil {
+		return value
+	}
+
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, id); err != nil {
+		return string(id)
+	}
+	return compact.String()
+}
@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestGetNodeNeighborsFollowsLabelledEdges(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			GremlinQuery string                 `json:"gremlinQuery"`
+			Bindings     map[string]interface{} `json:"bindings"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+
+		if calls.Add(1) == 1 {
+			if body.GremlinQuery != "g.V().hasId(binding_0).repeat(outE().hasLabel(within(binding_1)).inV()).emit().times(binding_2).not(hasId(binding_3)).dedup().valueMap(true)" {
+				t.Errorf("unexpected node query: %s", body.GremlinQuery)
+			}
+			if body.Bindings["binding_0"] != "cluster-1" || body.Bindings["binding_2"] != float64(2) || body.Bindings["binding_3"] != "cluster-1" {
+				t.Errorf("unexpected node bindings: %v", body.Bindings)
+			}
+			_, _ = w.Write([]byte(`{"gremlinQueryResults":[{"id":"cluster-1"},{"id":"host-1","name":["host one"]},{"id":"host-2"}]}`))
+			return
+		}
+
+		if !strings.HasPrefix(body.GremlinQuery, "g.V().hasId(within(binding_0)).outE().hasLabel(within(binding_1))") {
+			t.Errorf("unexpected edge query: %s", body.GremlinQuery)
+		}
+		if ids, ok := body.Bindings["binding_0"].([]interface{}); !ok || len(ids) != 3 {
+			t.Errorf("unexpected edge bindings: %v", body.Bindings)
+		}
+		_, _ = w.Write([]byte(`{"gremlinQueryResults":[{"id":"edge-1","label":"hosts","outV":"cluster-1","inV":"host-1"}]}`))
+	})
+
+	nodes, edges, err := client.GetNodeNeighbors(context.Background(), neighborTraversal{
+		NodeID:     "cluster-1",
+		Direction:  "out",
+		EdgeLabels: []string{"hosts"},
+		Depth:      2,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(nodes) != 2 || nodes[0].ID != "host-1" || nodes[1].ID != "host-2" {
+		t.Fatalf("expected the starting node to be excluded, got %+v", nodes)
+	}
+	if len(edges) != 1 || edges[0].FromNodeID != "cluster-1" || edges[0].ToNodeID != "host-1" || edges[0].Label != "hosts" {
+		t.Fatalf("unexpected edges: %+v", edges)
+	}
+}
+
+func TestGetNodeNeighborsSkipsEdgesWithoutNeighbors(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`{"gremlinQueryResults":[]}`))
+	})
+
+	nodes, edges, err := client.GetNodeNeighbors(context.Background(), neighborTraversal{NodeID: "lonely", Direction: "both", Depth: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(nodes) != 0 || len(edges) != 0 || calls.Load() != 1 {
+		t.Fatalf("expected a single query and no results, got %d nodes, %d edges after %d queries", len(nodes), len(edges), calls.Load())
+	}
+}
+
+func TestGetNodeNeighborsLimitExcludesStartingNode(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			GremlinQuery string                 `json:"gremlinQuery"`
+			Bindings     map[string]interface{} `json:"bindings"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+
+		if !strings.HasPrefix(body.GremlinQuery, "g.V().hasId(binding_0).repeat(bothE().otherV())") {
+			_, _ = w.Write([]byte(`{"gremlinQueryResults":[]}`))
+			return
+		}
+		if !strings.Contains(body.GremlinQuery, ".not(hasId(binding_2)).dedup().limit(binding_3)") {
+			t.Errorf("expected the starting node to be excluded before the limit, got: %s", body.GremlinQuery)
+		}
+		if body.Bindings["binding_2"] != "host-1" || body.Bindings["binding_3"] != float64(2) {
+			t.Errorf("unexpected node bindings: %v", body.Bindings)
+		}
+		// A cycle leads back to the starting node
+		_, _ = w.Write([]byte(`{"gremlinQueryResults":[{"id":"host-1"},{"id":"app-1"},{"id":"app-2"}]}`))
+	})
+
+	nodes, _, err := client.GetNodeNeighbors(context.Background(), neighborTraversal{NodeID: "host-1", Direction: "both", Depth: 3, Limit: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(nodes) != 2 || nodes[0].ID != "app-1" || nodes[1].ID != "app-2" {
+		t.Fatalf("expected both neighbors without the starting node, got %+v", nodes)
+	}
+}
+
+func TestGremlinEdgeNonStringIDs(t *testing.T) {
+	var edges []GremlinEdge
+	body := `[{"id":42,"label":"hosts","outV":"cluster-1","inV":"host-1"},{"id":{"relationId":"4r-9-2dh-3"},"label":"hosts","outV":7,"inV":8}]`
+	if err := json.Unmarshal([]byte(body), &edges); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if edges[0].ID != "42" || edges[0].FromNodeID != "cluster-1" {
+		t.Errorf("unexpected edge: %+v", edges[0])
+	}
+	if edges[1].ID != `{"relationId":"4r-9-2dh-3"}` || edges[1].FromNodeID != "7" || edges[1].ToNodeID != "8" {
+		t.Errorf("unexpected edge: %+v", edges[1])
+	}
+}
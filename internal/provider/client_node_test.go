@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetNodesSendsBindings(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			GremlinQuery string                 `json:"gremlinQuery"`
+			Bindings     map[string]interface{} `json:"bindings"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(body.GremlinQuery, "O'Brien") {
+			t.Errorf("node name was interpolated into the query: %s", body.GremlinQuery)
+		}
+		if body.Bindings["binding_1"] != "O'Brien's server" {
+			t.Errorf("unexpected bindings: %v", body.Bindings)
+		}
+		_, _ = w.Write([]byte(`{"gremlinQueryResults":[{"id":"node-1","name":["O'Brien's server"]}]}`))
+	})
+
+	nodes, err := client.GetNodes(context.Background(), nodeFilter{DataSourceID: "config-1", Name: "O'Brien's server"}, false, graphQueryWait{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(nodes) != 1 || nodes[0].ID != "node-1" {
+		t.Fatalf("unexpected nodes: %+v", nodes)
+	}
+}
+
+func TestGetNodesFailsFastWithoutWaiting(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`{"gremlinQueryResults":[]}`))
+	})
+
+	_, err := client.GetNodes(context.Background(), nodeFilter{DataSourceID: "config-1", Name: "missing"}, false, graphQueryWait{Enabled: false})
+	if err == nil {
+		t.Fatal("expected an error for missing nodes")
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("expected a single lookup, got %d", calls.Load())
+	}
+}
+
+func TestGetNodesPollsUntilNodesAppear(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			_, _ = w.Write([]byte(`{"gremlinQueryResults":[]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"gremlinQueryResults":[{"id":"node-1"}]}`))
+	})
+
+	wait := graphQueryWait{Enabled: true, Timeout: time.Second, PollInterval: time.Millisecond}
+	nodes, err := client.GetNodes(context.Background(), nodeFilter{DataSourceID: "config-1", Name: "new-node"}, false, wait)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(nodes) != 1 || calls.Load() != 3 {
+		t.Fatalf("expected nodes after 3 lookups, got %d nodes after %d lookups", len(nodes), calls.Load())
+	}
+}
+
+func TestGetNodesAllowsNoDataAfterTimeout(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"gremlinQueryResults":[]}`))
+	})
+
+	wait := graphQueryWait{Enabled: true, Timeout: 5 * time.Millisecond, PollInterval: time.Millisecond}
+	nodes, err := client.GetNodes(context.Background(), nodeFilter{DataSourceID: "config-1", Name: "missing"}, true, wait)
+	if err != nil || len(nodes) != 0 {
+		t.Fatalf("expected no nodes and no error, got %v, %v", nodes, err)
+	}
+}
+
+func TestGetNodesAppliesFilters(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			GremlinQuery string                 `json:"gremlinQuery"`
+			Bindings     map[string]interface{} `json:"bindings"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(body.GremlinQuery, "environment") || strings.Contains(body.GremlinQuery, ".limit(") {
+			t.Errorf("unexpected query: %s", body.GremlinQuery)
+		}
+		_, _ = w.Write([]byte(`{"gremlinQueryResults":[
+			{"id":"node-1","name":["web-01"],"type":["host"],"environment":["prod"],"region":["eu-west-1"]},
+			{"id":"node-2","name":["db-01"],"type":["host"],"environment":["prod"],"region":["eu-west-1"]},
+			{"id":"node-3","name":["web-02"],"type":["host"],"environment":["prod"],"region":["us-east-1"]},
+			{"id":"node-4","name":["web-03"],"type":["host"],"environment":["prod"],"region":["eu-west-2"]}
+		]}`))
+	})
+
+	filter := nodeFilter{
+		DataSourceID:  "config-1",
+		NamePrefix:    "web-",
+		Type:          "host",
+		Properties:    map[string]string{"environment": "prod"},
+		PropertyRegex: map[string]*regexp.Regexp{"region": regexp.MustCompile(`^eu-`)},
+		Limit:         1,
+	}
+
+	nodes, err := client.GetNodes(context.Background(), filter, false, graphQueryWait{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(nodes) != 1 || nodes[0].ID != "node-1" {
+		t.Fatalf("unexpected nodes: %+v", nodes)
+	}
+	if propertyString(nodes[0].Properties["region"]) != "eu-west-1" {
+		t.Fatalf("unexpected properties: %v", nodes[0].Properties)
+	}
+}
@@ -1,18 +1,19 @@
 package provider
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"strings"
 )
 
-func (c *SquaredUpClient) CreateScope(scope ScopeCreate, workspaceId string) (string, error) {
+func (c *SquaredUpClient) CreateScope(ctx context.Context, scope ScopeCreate, workspaceId string) (string, error) {
 	rb, err := json.Marshal(scope)
 	if err != nil {
 		return "", err
 	}
 
-	req, err := http.NewRequest("POST", c.baseURL+"/api/workspaces/"+workspaceId+"/scopes", strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/api/workspaces/"+workspaceId+"/scopes", strings.NewReader(string(rb)))
 	if err != nil {
 		return "", err
 	}
@@ -28,8 +29,8 @@ func (c *SquaredUpClient) CreateScope(scope ScopeCreate, workspaceId string) (st
 	return scopeID, nil
 }
 
-func (c *SquaredUpClient) GetScope(scopeId string, workspaceId string) (*ScopeRead, error) {
-	req, err := http.NewRequest("GET", c.baseURL+"/api/workspaces/"+workspaceId+"/scopes/"+scopeId, nil)
+func (c *SquaredUpClient) GetScope(ctx context.Context, scopeId string, workspaceId string) (*ScopeRead, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/api/workspaces/"+workspaceId+"/scopes/"+scopeId, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -48,13 +49,13 @@ func (c *SquaredUpClient) GetScope(scopeId string, workspaceId string) (*ScopeRe
 	return &scope, nil
 }
 
-func (c *SquaredUpClient) UpdateScope(scopeId string, scope ScopeCreate, workspaceId string) error {
+func (c *SquaredUpClient) UpdateScope(ctx context.Context, scopeId string, scope ScopeCreate, workspaceId string) error {
 	rb, err := json.Marshal(scope)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("PUT", c.baseURL+"/api/workspaces/"+workspaceId+"/scopes/"+scopeId, strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", c.baseURL+"/api/workspaces/"+workspaceId+"/scopes/"+scopeId, strings.NewReader(string(rb)))
 	if err != nil {
 		return err
 	}
@@ -67,8 +68,8 @@ func (c *SquaredUpClient) UpdateScope(scopeId string, scope ScopeCreate, workspa
 	return nil
 }
 
-func (c *SquaredUpClient) DeleteScope(scopeId string, workspaceId string) error {
-	req, err := http.NewRequest("DELETE", c.baseURL+"/api/workspaces/"+workspaceId+"/scopes/"+scopeId, nil)
+func (c *SquaredUpClient) DeleteScope(ctx context.Context, scopeId string, workspaceId string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.baseURL+"/api/workspaces/"+workspaceId+"/scopes/"+scopeId, nil)
 	if err != nil {
 		return err
 	}
@@ -1,18 +1,19 @@
 package provider
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"strings"
 )
 
-func (c *SquaredUpClient) CreateScript(script Script) (*Script, error) {
+func (c *SquaredUpClient) CreateScript(ctx context.Context, script Script) (*Script, error) {
 	rb, err := json.Marshal(script)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", c.baseURL+"/api/scripts", strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/api/scripts", strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
@@ -31,8 +32,8 @@ func (c *SquaredUpClient) CreateScript(script Script) (*Script, error) {
 	return &newScript, nil
 }
 
-func (c *SquaredUpClient) GetScript(scriptId string) (*Script, error) {
-	req, err := http.NewRequest("GET", c.baseURL+"/api/scripts/"+scriptId, nil)
+func (c *SquaredUpClient) GetScript(ctx context.Context, scriptId string) (*Script, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/api/scripts/"+scriptId, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -51,13 +52,13 @@ func (c *SquaredUpClient) GetScript(scriptId string) (*Script, error) {
 	return &script, nil
 }
 
-func (c *SquaredUpClient) UpdateScript(scriptId string, script Script) error {
+func (c *SquaredUpClient) UpdateScript(ctx context.Context, scriptId string, script Script) error {
 	rb, err := json.Marshal(script)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("PUT", c.baseURL+"/api/scripts/"+scriptId, strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", c.baseURL+"/api/scripts/"+scriptId, strings.NewReader(string(rb)))
 	if err != nil {
 		return err
 	}
@@ -70,8 +71,8 @@ func (c *SquaredUpClient) UpdateScript(scriptId string, script Script) error {
 	return nil
 }
 
-func (c *SquaredUpClient) DeleteScript(scriptId string) error {
-	req, err := http.NewRequest("DELETE", c.baseURL+"/api/scripts/"+scriptId, nil)
+func (c *SquaredUpClient) DeleteScript(ctx context.Context, scriptId string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.baseURL+"/api/scripts/"+scriptId, nil)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,286 @@
+package provider
+
+import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *SquaredUpClient {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return &SquaredUpClient{
+		baseURL:      server.URL,
+		apiKey:       "test-key",
+		httpClient:   server.Client(),
+		version:      "test",
+		maxRetries:   3,
+		retryMaxWait: 10 * time.Millisecond,
+	}
+}
+
+func TestDoRequestRetriesIdempotentServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`"ok"`))
+	})
+
+	req, _ := http.NewRequest("PUT", client.baseURL+"/api/scripts/1", strings.NewReader(`{"a":1}`))
+	body, err := client.doRequest(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(body) != `"ok"` {
+		t.Fatalf("unexpected body: %s", body)
+	}
+	if calls.Load() != 3 {
+		t.Fatalf("expected 3 attempts, got %d", calls.Load())
+	}
+}
+
+func TestDoRequestDoesNotReplayPostOnServerError(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	req, _ := http.NewRequest("POST", client.baseURL+"/api/dashboards", strings.NewReader(`{}`))
+	if _, err := client.doRequest(req); err == nil {
+		t.Fatal("expected error")
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("expected 1 attempt, got %d", calls.Load())
+	}
+}
+
+func TestDoRequestRetriesPostOnTooManyRequests(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	req, _ := http.NewRequest("POST", client.baseURL+"/api/dashboards", strings.NewReader(`{}`))
+	if _, err := client.doRequest(req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls.Load() != 2 {
+		t.Fatalf("expected 2 attempts, got %d", calls.Load())
+	}
+}
+
+func TestDoRequestGivesUpWhenRetryAfterExceedsMaxWait(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	req, _ := http.NewRequest("GET", client.baseURL+"/api/workspaces", nil)
+	if _, err := client.doRequest(req); err == nil {
+		t.Fatal("expected error")
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("expected 1 attempt, got %d", calls.Load())
+	}
+}
+
+func TestDoRequestStopsWaitingWhenContextIsCancelled(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	client.retryMaxWait = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, "GET", client.baseURL+"/api/workspaces", nil)
+	start := time.Now()
+	_, err := client.doRequest(req)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got: %v", err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Fatal("doRequest did not return promptly after cancellation")
+	}
+}
+
+func TestDoRequestReturnsAPIError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-123")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Dashboard not found"}`))
+	})
+
+	req, _ := http.NewRequest("GET", client.baseURL+"/api/dashboards/dash-1", nil)
+	_, err := client.doRequest(req)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected APIError, got: %v", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.RequestID != "req-123" || apiErr.Message != "Dashboard not found" {
+		t.Fatalf("unexpected APIError: %+v", apiErr)
+	}
+	if !isNotFound(err) {
+		t.Fatal("expected isNotFound to be true")
+	}
+}
+
+func TestDoRequestSendsAPIKeyInQueryByDefault(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("apiKey") != "test-key" {
+			t.Errorf("unexpected apiKey query parameter: %q", r.URL.Query().Get("apiKey"))
+		}
+		if r.Header.Get("Authorization") != "" {
+			t.Error("api key must not be sent in a header unless configured")
+		}
+	})
+
+	req, _ := http.NewRequest("GET", client.baseURL+"/api/workspaces", nil)
+	if _, err := client.doRequest(req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestDoRequestSendsAPIKeyInHeader(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("apiKey") != "" {
+			t.Error("api key must not be sent in the query string")
+		}
+		if r.Header.Get("Authorization") != "Bearer test-key" {
+			t.Errorf("unexpected Authorization header: %q", r.Header.Get("Authorization"))
+		}
+	})
+	client.authTransport = authTransportHeader
+
+	req, _ := http.NewRequest("GET", client.baseURL+"/api/workspaces", nil)
+	if _, err := client.doRequest(req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestDoRequestScrubsAPIKeyFromErrors(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	client := &SquaredUpClient{
+		baseURL:       server.URL,
+		apiKey:        "secret-key",
+		authTransport: authTransportQuery,
+		httpClient:    http.DefaultClient,
+		version:       "test",
+	}
+
+	req, _ := http.NewRequest("GET", client.baseURL+"/api/workspaces", nil)
+	_, err := client.doRequest(req)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if strings.Contains(err.Error(), "secret-key") {
+		t.Fatalf("api key leaked into error: %v", err)
+	}
+}
+
+func TestDetermineBaseURL(t *testing.T) {
+	cases := []struct {
+		region            string
+		allowInsecureHTTP bool
+		want              string
+		wantErr           bool
+	}{
+		{region: "us", want: "https://api.squaredup.com"},
+		{region: "eu", want: "https://eu.api.squaredup.com"},
+		{region: "https://squaredup.example.com/", want: "https://squaredup.example.com"},
+		{region: "http://127.0.0.1:8080", wantErr: true},
+		{region: "http://127.0.0.1:8080/", allowInsecureHTTP: true, want: "http://127.0.0.1:8080"},
+		{region: "ftp://example.com", allowInsecureHTTP: true, wantErr: true},
+	}
+
+	for _, tc := range cases {
+		got, err := determineBaseURL(tc.region, tc.allowInsecureHTTP)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("determineBaseURL(%q) expected error", tc.region)
+			}
+			continue
+		}
+		if err != nil || got != tc.want {
+			t.Errorf("determineBaseURL(%q) = %q, %v; want %q", tc.region, got, err, tc.want)
+		}
+	}
+}
+
+func TestNewSquaredUpClientTrustsCustomCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	t.Cleanup(server.Close)
+
+	caCertPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	if _, err := NewSquaredUpClient(context.Background(), server.URL, "test-key", "test", SquaredUpClientOptions{}); err == nil {
+		t.Fatal("expected an error without the custom certificate authority")
+	}
+
+	_, err := NewSquaredUpClient(context.Background(), server.URL, "test-key", "test", SquaredUpClientOptions{
+		CACertPEM: string(caCertPEM),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestNewSquaredUpClientReportsInvalidAPIKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	t.Cleanup(server.Close)
+
+	_, err := NewSquaredUpClient(context.Background(), server.URL, "bad-key", "test", SquaredUpClientOptions{AllowInsecureHTTP: true})
+	if err == nil || !strings.Contains(err.Error(), "invalid api key") {
+		t.Fatalf("expected invalid api key error, got: %v", err)
+	}
+}
+
+func TestNewSquaredUpClientReportsNetworkErrors(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	_, err := NewSquaredUpClient(context.Background(), server.URL, "test-key", "test", SquaredUpClientOptions{AllowInsecureHTTP: true})
+	if err == nil || strings.Contains(err.Error(), "invalid api key") {
+		t.Fatalf("expected a network error, got: %v", err)
+	}
+}
+
+func TestNewSquaredUpClientSkipsCredentialsValidation(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	_, err := NewSquaredUpClient(context.Background(), server.URL, "test-key", "test", SquaredUpClientOptions{
+		AllowInsecureHTTP:         true,
+		SkipCredentialsValidation: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
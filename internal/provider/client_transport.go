@@ -0,0 +1,225 @@
+package provider
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const maxLoggedBodyBytes = 64 * 1024
+
+const redactedValue = "REDACTED"
+
+// topLevelSensitiveKeys are masked when they appear at the root of a payload.
+// Data sources, alerting channels and scripts keep their credentials under a
+// root "config" object, whereas dashboard tiles use "config" for harmless
+// visualisation settings.
+var topLevelSensitiveKeys = []string{"config"}
+
+// sensitiveKeys are masked wherever they appear in a payload.
+var sensitiveKeys = []string{
+	"accesskey",
+	"apikey",
+	"authorization",
+	"clientsecret",
+	"password",
+	"privatekey",
+	"secret",
+	"secretkey",
+	"token",
+	"webhookurl",
+}
+
+// newHTTPTransport builds the transport used to reach the SquaredUp API,
+// applying the proxy and certificate authority settings from the provider.
+func newHTTPTransport(options SquaredUpClientOptions) (http.RoundTripper, error) {
+	defaultTransport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("unexpected default transport type: %T", http.DefaultTransport)
+	}
+	transport := defaultTransport.Clone()
+
+	if options.HTTPProxy != "" {
+		proxyURL, err := url.Parse(options.HTTPProxy)
+		if err != nil || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid http proxy url: %s", options.HTTPProxy)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	caCertPEM := []byte(options.CACertPEM)
+	if options.CACertFile != "" {
+		fileContents, err := os.ReadFile(options.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read ca certificate file: %w", err)
+		}
+		caCertPEM = append(caCertPEM, '\n')
+		caCertPEM = append(caCertPEM, fileContents...)
+	}
+
+	if len(bytes.TrimSpace(caCertPEM)) > 0 {
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil || rootCAs == nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM(caCertPEM) {
+			return nil, fmt.Errorf("no valid PEM encoded certificates found in the ca certificate bundle")
+		}
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs:    rootCAs,
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+
+	return transport, nil
+}
+
+// loggingTransport logs every SquaredUp API call with tflog. Method, path,
+// status, latency and size are logged at DEBUG, redacted bodies at TRACE.
+type loggingTransport struct {
+	next    http.RoundTripper
+	secrets []string
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	for _, secret := range t.secrets {
+		if secret != "" {
+			ctx = tflog.MaskAllFieldValuesStrings(ctx, secret)
+			ctx = tflog.MaskMessageStrings(ctx, secret)
+		}
+	}
+
+	fields := map[string]interface{}{
+		"http_method": req.Method,
+		"http_path":   req.URL.Path,
+	}
+
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			requestBody, _ := io.ReadAll(body)
+			body.Close()
+			tflog.Trace(ctx, "SquaredUp API request body", mergeFields(fields, map[string]interface{}{
+				"http_request_body": redactBody(requestBody),
+			}))
+		}
+	}
+
+	start := time.Now()
+	res, err := t.next.RoundTrip(req)
+	fields["http_duration_ms"] = time.Since(start).Milliseconds()
+
+	if err != nil {
+		tflog.Debug(ctx, "SquaredUp API request failed", mergeFields(fields, map[string]interface{}{
+			"error": err.Error(),
+		}))
+		return nil, err
+	}
+
+	responseBody, readErr := io.ReadAll(res.Body)
+	res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(responseBody))
+	if readErr != nil {
+		tflog.Debug(ctx, "Unable to read SquaredUp API response body", mergeFields(fields, map[string]interface{}{
+			"error": readErr.Error(),
+		}))
+		return res, nil
+	}
+
+	fields["http_status"] = res.StatusCode
+	fields["http_response_size"] = len(responseBody)
+
+	tflog.Debug(ctx, "SquaredUp API request", fields)
+	tflog.Trace(ctx, "SquaredUp API response body", mergeFields(fields, map[string]interface{}{
+		"http_response_body": redactBody(responseBody),
+	}))
+
+	return res, nil
+}
+
+func mergeFields(base map[string]interface{}, extra map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(base)+len(extra))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range extra {
+		merged[k] = v
+	}
+	return merged
+}
+
+// redactBody masks sensitive values in a JSON body so it can be logged.
+// Bodies that are not JSON are logged as they are.
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return truncateBody(string(body))
+	}
+
+	if object, ok := decoded.(map[string]interface{}); ok {
+		for key := range object {
+			if matchesKey(key, topLevelSensitiveKeys) {
+				object[key] = redactedValue
+			}
+		}
+	}
+
+	redacted, err := json.Marshal(redactValue(decoded))
+	if err != nil {
+		return redactedValue
+	}
+
+	return truncateBody(string(redacted))
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if matchesKey(key, sensitiveKeys) {
+				v[key] = redactedValue
+				continue
+			}
+			v[key] = redactValue(item)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func matchesKey(key string, keys []string) bool {
+	normalized := strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(key))
+	for _, k := range keys {
+		if normalized == k {
+			return true
+		}
+	}
+	return false
+}
+
+func truncateBody(body string) string {
+	if len(body) <= maxLoggedBodyBytes {
+		return body
+	}
+	return body[:maxLoggedBodyBytes] + "...(truncated)"
+}
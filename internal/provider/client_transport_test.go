@@ -0,0 +1,30 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRedactBody(t *testing.T) {
+	body := `{"displayName":"AWS","config":{"secretKey":"abc"},"content":{"contents":[{"config":{"title":"Tile"},"password":"hunter2"}]}}`
+
+	redacted := redactBody([]byte(body))
+
+	for _, secret := range []string{"abc", "hunter2"} {
+		if strings.Contains(redacted, secret) {
+			t.Fatalf("secret %q was not redacted: %s", secret, redacted)
+		}
+	}
+	if !strings.Contains(redacted, `"title":"Tile"`) {
+		t.Fatalf("nested tile config should not be redacted: %s", redacted)
+	}
+	if !strings.Contains(redacted, `"displayName":"AWS"`) {
+		t.Fatalf("non-sensitive fields should be kept: %s", redacted)
+	}
+}
+
+func TestRedactBodyKeepsNonJSON(t *testing.T) {
+	if got := redactBody([]byte("Internal Server Error")); got != "Internal Server Error" {
+		t.Fatalf("unexpected redacted body: %s", got)
+	}
+}
@@ -1,18 +1,31 @@
 package provider
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
-func (c *SquaredUpClient) CreateWorkspace(workspacePayload map[string]interface{}) (string, error) {
+func (c *SquaredUpClient) CreateWorkspace(ctx context.Context, workspacePayload map[string]interface{}) (string, error) {
+	// A new workspace has nothing to clear, so null properties are left out
+	if properties, ok := workspacePayload["properties"].(map[string]interface{}); ok {
+		for key, value := range properties {
+			if value == nil {
+				delete(properties, key)
+			}
+		}
+	}
+
 	rb, err := json.Marshal(workspacePayload)
 	if err != nil {
 		return "", err
 	}
 
-	req, err := http.NewRequest("POST", c.baseURL+"/api/workspaces", strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/api/workspaces", strings.NewReader(string(rb)))
 	if err != nil {
 		return "", err
 	}
@@ -28,8 +41,8 @@ func (c *SquaredUpClient) CreateWorkspace(workspacePayload map[string]interface{
 	return workspaceID, nil
 }
 
-func (c *SquaredUpClient) GetWorkspace(workspaceId string) (*WorkspaceRead, error) {
-	req, err := http.NewRequest("GET", c.baseURL+"/api/workspaces/"+workspaceId, nil)
+func (c *SquaredUpClient) GetWorkspace(ctx context.Context, workspaceId string) (*WorkspaceRead, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/api/workspaces/"+workspaceId, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -48,27 +61,92 @@ func (c *SquaredUpClient) GetWorkspace(workspaceId string) (*WorkspaceRead, erro
 	return &workspace, nil
 }
 
-func (c *SquaredUpClient) UpdateWorkspace(workspaceId string, workspacePayload map[string]interface{}) error {
-	rb, err := json.Marshal(workspacePayload)
+// UpdateWorkspace patches a workspace and returns it as read back after the
+// change. Workspace properties are shared by several resources, for example
+// squaredup_workspace and squaredup_dashboard_ordering, so the properties in
+// workspacePayload are merged into the current properties rather than
+// replacing them. The read, patch and read back all happen under the
+// workspace lock.
+func (c *SquaredUpClient) UpdateWorkspace(ctx context.Context, workspaceId string, workspacePayload map[string]interface{}) (*WorkspaceRead, error) {
+	unlock, err := c.lockWorkspace(ctx, workspaceId)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	defer unlock()
+
+	payload := workspacePayload
+	if properties, ok := workspacePayload["properties"].(map[string]interface{}); ok {
+		current, err := c.getWorkspaceProperties(ctx, workspaceId)
+		if err != nil {
+			return nil, err
+		}
+
+		merged := make(map[string]interface{}, len(current)+len(properties))
+		for key, value := range current {
+			merged[key] = value
+		}
+		for key, value := range properties {
+			merged[key] = value
+		}
+
+		payload = make(map[string]interface{}, len(workspacePayload))
+		for key, value := range workspacePayload {
+			payload[key] = value
+		}
+		payload["properties"] = merged
 	}
 
-	req, err := http.NewRequest("PATCH", c.baseURL+"/api/workspaces/"+workspaceId, strings.NewReader(string(rb)))
+	rb, err := json.Marshal(payload)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PATCH", c.baseURL+"/api/workspaces/"+workspaceId, strings.NewReader(string(rb)))
+	if err != nil {
+		return nil, err
 	}
 
 	_, err = c.doRequest(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return c.GetWorkspace(ctx, workspaceId)
+}
+
+// getWorkspaceProperties returns every property of a workspace, including
+// ones WorkspaceProperties doesn't model.
+func (c *SquaredUpClient) getWorkspaceProperties(ctx context.Context, workspaceId string) (map[string]interface{}, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/api/workspaces/"+workspaceId, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var workspace struct {
+		Data struct {
+			Properties map[string]interface{} `json:"properties"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &workspace); err != nil {
+		return nil, err
+	}
+
+	return workspace.Data.Properties, nil
 }
 
-func (c *SquaredUpClient) DeleteWorkspace(workspaceId string) error {
-	req, err := http.NewRequest("DELETE", c.baseURL+"/api/workspaces/"+workspaceId, nil)
+func (c *SquaredUpClient) DeleteWorkspace(ctx context.Context, workspaceId string) error {
+	unlock, err := c.lockWorkspace(ctx, workspaceId)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.baseURL+"/api/workspaces/"+workspaceId, nil)
 	if err != nil {
 		return err
 	}
@@ -80,3 +158,19 @@ func (c *SquaredUpClient) DeleteWorkspace(workspaceId string) error {
 
 	return nil
 }
+
+// lockWorkspace waits until no other mutation of workspaceId is in flight.
+func (c *SquaredUpClient) lockWorkspace(ctx context.Context, workspaceId string) (func(), error) {
+	start := time.Now()
+	unlock, err := c.workspaceLocks.Lock(ctx, workspaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	tflog.Debug(ctx, "Acquired SquaredUp workspace lock", map[string]interface{}{
+		"workspace_id": workspaceId,
+		"wait_ms":      time.Since(start).Milliseconds(),
+	})
+
+	return unlock, nil
+}
@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestUpdateWorkspaceKeepsOtherResourcesProperties(t *testing.T) {
+	var mu sync.Mutex
+	properties := map[string]interface{}{"description": "old"}
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.Method {
+		case http.MethodGet:
+			body, _ := json.Marshal(map[string]interface{}{
+				"id":   "space-1",
+				"data": map[string]interface{}{"properties": properties},
+			})
+			_, _ = w.Write(body)
+		case http.MethodPatch:
+			// Like the API, a patch replaces the properties object as a whole
+			var payload map[string]interface{}
+			body, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(body, &payload); err != nil {
+				t.Errorf("request body is not valid JSON: %v", err)
+			}
+			properties = payload["properties"].(map[string]interface{})
+		}
+	})
+
+	updates := []map[string]interface{}{
+		{"properties": map[string]interface{}{"description": "new"}},
+		{"properties": map[string]interface{}{"dashboardIdOrder": []interface{}{"dash-1"}}},
+	}
+
+	var wg sync.WaitGroup
+	for _, update := range updates {
+		wg.Add(1)
+		go func(update map[string]interface{}) {
+			defer wg.Done()
+			if _, err := client.UpdateWorkspace(context.Background(), "space-1", update); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}(update)
+	}
+	wg.Wait()
+
+	expected := map[string]interface{}{
+		"description":      "new",
+		"dashboardIdOrder": []interface{}{"dash-1"},
+	}
+	if !reflect.DeepEqual(properties, expected) {
+		t.Errorf("expected both updates to survive, got %v", properties)
+	}
+}
+
+func TestUpdateWorkspaceClearsUnsetProperties(t *testing.T) {
+	properties := map[string]interface{}{
+		"description":      "old",
+		"type":             "team",
+		"dashboardIdOrder": []interface{}{"dash-1"},
+	}
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			body, _ := json.Marshal(map[string]interface{}{
+				"id":   "space-1",
+				"data": map[string]interface{}{"properties": properties},
+			})
+			_, _ = w.Write(body)
+		case http.MethodPatch:
+			var payload map[string]interface{}
+			body, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(body, &payload); err != nil {
+				t.Errorf("request body is not valid JSON: %v", err)
+			}
+			properties = payload["properties"].(map[string]interface{})
+		}
+	})
+
+	// description and type were removed from the configuration
+	plan := workspace{
+		DisplayName: types.StringValue("Workspace"),
+		Description: types.StringUnknown(),
+		Type:        types.StringUnknown(),
+	}
+	if _, err := client.UpdateWorkspace(context.Background(), "space-1", GenerateWorkspacePayload(plan)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, key := range []string{"description", "type"} {
+		if value, ok := properties[key]; !ok || value != nil {
+			t.Errorf("expected %s to be cleared with null, got %v", key, properties[key])
+		}
+	}
+	if properties["dashboardIdOrder"] == nil {
+		t.Error("expected properties the workspace resource doesn't manage to be kept")
+	}
+}
+
+func TestCreateWorkspaceOmitsNullProperties(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Errorf("request body is not valid JSON: %v", err)
+		}
+		properties := payload["properties"].(map[string]interface{})
+		if _, ok := properties["type"]; ok {
+			t.Errorf("expected type to be left out, got %v", properties["type"])
+		}
+		_, _ = w.Write([]byte(`"space-1"`))
+	})
+
+	payload := GenerateWorkspacePayload(workspace{DisplayName: types.StringValue("Workspace"), Type: types.StringNull()})
+	if _, err := client.CreateWorkspace(context.Background(), payload); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -71,7 +71,7 @@ func (d *squaredupAlertingChannelType) Read(ctx context.Context, req datasource.
 		return
 	}
 
-	alertingChannelTypes, err := d.client.GetAlertingChannelTypes(state.DisplayName.ValueString())
+	alertingChannelTypes, err := d.client.GetAlertingChannelTypes(ctx, state.DisplayName.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to get alerting channel types",
@@ -75,7 +75,7 @@ func (d *squaredupLatestDataSource) Read(ctx context.Context, req datasource.Rea
 		return
 	}
 
-	plugins, err := d.client.GetLatestDataSources(state.DataSourceName.ValueString(), state.OnPrem.ValueBoolPointer())
+	plugins, err := d.client.GetLatestDataSources(ctx, state.DataSourceName.ValueString(), state.OnPrem.ValueBoolPointer())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error making API request to fetch latest Data Sources",
@@ -74,7 +74,7 @@ func (d *squaredupDataStream) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
-	dataStreams, err := d.client.GetDataStreams(state.DataSourceID.ValueString(), state.DataStreamName.ValueString())
+	dataStreams, err := d.client.GetDataStreams(ctx, state.DataSourceID.ValueString(), state.DataStreamName.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to get data streams",
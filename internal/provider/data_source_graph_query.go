@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &squaredupGraphQuery{}
+	_ datasource.DataSourceWithConfigure = &squaredupGraphQuery{}
+)
+
+func SquaredUpGraphQuery() datasource.DataSource {
+	return &squaredupGraphQuery{}
+}
+
+type squaredupGraphQuery struct {
+	client *SquaredUpClient
+}
+
+type squaredupGraphQueryModel struct {
+	Query          types.String  `tfsdk:"query"`
+	Bindings       types.Dynamic `tfsdk:"bindings"`
+	Results        types.Dynamic `tfsdk:"results"`
+	WaitForResults types.Bool    `tfsdk:"wait_for_results"`
+	Timeout        types.Int64   `tfsdk:"timeout"`
+	PollInterval   types.Int64   `tfsdk:"poll_interval"`
+}
+
+func (d *squaredupGraphQuery) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_graph_query"
+}
+
+func (d *squaredupGraphQuery) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Runs a Gremlin query against the SquaredUp graph",
+		Attributes: map[string]schema.Attribute{
+			"query": schema.StringAttribute{
+				MarkdownDescription: "Gremlin query to run, for example `g.V().has('__configId', configId).limit(10).valueMap(true)`. Refer to values from `bindings` by name instead of interpolating them into the query.",
+				Required:            true,
+			},
+			"bindings": schema.DynamicAttribute{
+				MarkdownDescription: "Object of values the query refers to by name. Values may be strings, numbers, booleans, lists or objects.",
+				Optional:            true,
+			},
+			"results": schema.DynamicAttribute{
+				MarkdownDescription: "List of query results. Each result keeps the shape returned by the graph API, for example an object of properties for `valueMap(true)`.",
+				Computed:            true,
+			},
+			"wait_for_results": schema.BoolAttribute{
+				MarkdownDescription: "If true, keep running the query until it returns results or `timeout` is reached. Defaults to false.",
+				Optional:            true,
+			},
+			"timeout": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of seconds to wait for results when `wait_for_results` is true. Defaults to 300.",
+				Optional:            true,
+				Validators:          []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"poll_interval": schema.Int64Attribute{
+				MarkdownDescription: "Number of seconds between queries when `wait_for_results` is true. Defaults to 30.",
+				Optional:            true,
+				Validators:          []validator.Int64{int64validator.AtLeast(1)},
+			},
+		},
+	}
+}
+
+func (d *squaredupGraphQuery) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*SquaredUpClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unable to cast provider data to SquaredUpClient",
+			fmt.Sprintf("Expected *SquaredUpClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *squaredupGraphQuery) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state squaredupGraphQueryModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var bindings map[string]interface{}
+	if !state.Bindings.IsNull() {
+		value, err := dynamicToGo(ctx, state.Bindings)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("bindings"), "Invalid Bindings", err.Error())
+			return
+		}
+
+		var ok bool
+		bindings, ok = value.(map[string]interface{})
+		if !ok {
+			resp.Diagnostics.AddAttributeError(path.Root("bindings"), "Invalid Bindings", "Bindings must be an object of named values")
+			return
+		}
+	}
+
+	wait := graphQueryWait{
+		Enabled:      state.WaitForResults.ValueBool(),
+		Timeout:      defaultGraphQueryTimeout,
+		PollInterval: defaultGraphQueryPollInterval,
+	}
+	if !state.Timeout.IsNull() {
+		wait.Timeout = time.Duration(state.Timeout.ValueInt64()) * time.Second
+	}
+	if !state.PollInterval.IsNull() {
+		wait.PollInterval = time.Duration(state.PollInterval.ValueInt64()) * time.Second
+	}
+
+	results, err := d.client.GraphQuery(ctx, state.Query.ValueString(), bindings, wait)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Run Graph Query",
+			err.Error(),
+		)
+		return
+	}
+
+	resultsValue, diags := jsonToAttrValue(results)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Results = types.DynamicValue(resultsValue)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/pborman/uuid"
+)
+
+func TestGraphQueryDataSource(t *testing.T) {
+	uuid := uuid.NewRandom().String()
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig +
+					`
+data "squaredup_datasources" "sample_data" {
+	data_source_name = "Sample Data"
+}
+
+resource "squaredup_datasource" "sample_data_source" {
+	display_name     = "Sample Data - Graph Query Test - ` + uuid + `"
+	data_source_name = data.squaredup_datasources.sample_data.plugins[0].display_name
+}
+
+data "squaredup_graph_query" "acc_common_node" {
+	query            = "g.V().has('__configId', configId).has('name', name).valueMap(true)"
+	wait_for_results = true
+	bindings = {
+		configId = squaredup_datasource.sample_data_source.id
+		name     = "account-common-lambda"
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.squaredup_graph_query.acc_common_node", "results.0.id"),
+					resource.TestCheckResourceAttr("data.squaredup_graph_query.acc_common_node", "results.0.name.0", "account-common-lambda"),
+				),
+			},
+		},
+	})
+}
@@ -3,9 +3,16 @@ package provider
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -27,7 +34,15 @@ type squaredupNodesResponse struct {
 	DataSourceID   types.String               `tfsdk:"data_source_id"`
 	NodeName       types.String               `tfsdk:"node_name"`
 	NodeSourceID   types.String               `tfsdk:"node_source_id"`
+	NodeNamePrefix types.String               `tfsdk:"node_name_prefix"`
+	NodeType       types.String               `tfsdk:"node_type"`
+	Properties     map[string]string          `tfsdk:"properties"`
+	PropertyRegex  map[string]string          `tfsdk:"property_regex"`
+	Limit          types.Int64                `tfsdk:"limit"`
 	AllowNoData    types.Bool                 `tfsdk:"allow_no_data"`
+	WaitForNodes   types.Bool                 `tfsdk:"wait_for_nodes"`
+	Timeout        types.Int64                `tfsdk:"timeout"`
+	PollInterval   types.Int64                `tfsdk:"poll_interval"`
 }
 
 type squaredupNodesProperties struct {
@@ -36,6 +51,7 @@ type squaredupNodesProperties struct {
 	DisplayName types.String `tfsdk:"display_name"`
 	SourceID    types.String `tfsdk:"source_id"`
 	Type        types.String `tfsdk:"type"`
+	Properties  types.Map    `tfsdk:"properties"`
 }
 
 func (d *squaredupNodes) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -55,6 +71,11 @@ func (d *squaredupNodes) Schema(_ context.Context, req datasource.SchemaRequest,
 						"display_name": schema.StringAttribute{Computed: true},
 						"source_id":    schema.StringAttribute{Computed: true},
 						"type":         schema.StringAttribute{Computed: true},
+						"properties": schema.MapAttribute{
+							MarkdownDescription: "All properties of the node. Properties with several values are JSON encoded.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
 					},
 				},
 			},
@@ -70,10 +91,47 @@ func (d *squaredupNodes) Schema(_ context.Context, req datasource.SchemaRequest,
 				MarkdownDescription: "Node Source ID",
 				Optional:            true,
 			},
+			"node_name_prefix": schema.StringAttribute{
+				MarkdownDescription: "Only return nodes whose name starts with this prefix",
+				Optional:            true,
+			},
+			"node_type": schema.StringAttribute{
+				MarkdownDescription: "Only return nodes of this type, for example `host`",
+				Optional:            true,
+			},
+			"properties": schema.MapAttribute{
+				MarkdownDescription: "Only return nodes whose properties equal all of the given values, for example `{ environment = \"prod\" }`",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"property_regex": schema.MapAttribute{
+				MarkdownDescription: "Only return nodes whose properties match all of the given regular expressions",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of nodes to return",
+				Optional:            true,
+				Validators:          []validator.Int64{int64validator.AtLeast(1)},
+			},
 			"allow_no_data": schema.BoolAttribute{
 				MarkdownDescription: "If true, the data source will return an empty list if its unable to find the node.",
 				Optional:            true,
 			},
+			"wait_for_nodes": schema.BoolAttribute{
+				MarkdownDescription: "If true (default), keep polling until matching nodes are found or `timeout` is reached. This is useful right after a data source is created, while its objects are still being indexed. Set to false to fail fast.",
+				Optional:            true,
+			},
+			"timeout": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of seconds to wait for matching nodes when `wait_for_nodes` is true. Defaults to 300.",
+				Optional:            true,
+				Validators:          []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"poll_interval": schema.Int64Attribute{
+				MarkdownDescription: "Number of seconds between lookups when `wait_for_nodes` is true. Defaults to 30.",
+				Optional:            true,
+				Validators:          []validator.Int64{int64validator.AtLeast(1)},
+			},
 		},
 	}
 }
@@ -110,7 +168,49 @@ func (d *squaredupNodes) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
-	nodes, err := d.client.GetNodes(state.DataSourceID.ValueString(), state.NodeName.ValueString(), state.NodeSourceID.ValueString(), state.AllowNoData.ValueBool())
+	filter := nodeFilter{
+		DataSourceID:  state.DataSourceID.ValueString(),
+		Name:          state.NodeName.ValueString(),
+		SourceID:      state.NodeSourceID.ValueString(),
+		NamePrefix:    state.NodeNamePrefix.ValueString(),
+		Type:          state.NodeType.ValueString(),
+		Properties:    state.Properties,
+		PropertyRegex: make(map[string]*regexp.Regexp, len(state.PropertyRegex)),
+		Limit:         int(state.Limit.ValueInt64()),
+	}
+
+	for key, pattern := range state.PropertyRegex {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("property_regex").AtMapKey(key),
+				"Invalid Regular Expression",
+				fmt.Sprintf("Unable to compile the regular expression for property %s: %s", key, err.Error()),
+			)
+			continue
+		}
+		filter.PropertyRegex[key] = compiled
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	wait := graphQueryWait{
+		Enabled:      true,
+		Timeout:      defaultGraphQueryTimeout,
+		PollInterval: defaultGraphQueryPollInterval,
+	}
+	if !state.WaitForNodes.IsNull() {
+		wait.Enabled = state.WaitForNodes.ValueBool()
+	}
+	if !state.Timeout.IsNull() {
+		wait.Timeout = time.Duration(state.Timeout.ValueInt64()) * time.Second
+	}
+	if !state.PollInterval.IsNull() {
+		wait.PollInterval = time.Duration(state.PollInterval.ValueInt64()) * time.Second
+	}
+
+	nodes, err := d.client.GetNodes(ctx, filter, state.AllowNoData.ValueBool(), wait)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Retrieve Nodes",
@@ -121,12 +221,10 @@ func (d *squaredupNodes) Read(ctx context.Context, req datasource.ReadRequest, r
 
 	var NodeProperties []squaredupNodesProperties
 	for _, node := range nodes {
-		nodeProperties := squaredupNodesProperties{
-			ID:          types.StringValue(node.ID),
-			SourceName:  types.StringValue(node.SourceName[0]),
-			DisplayName: types.StringValue(node.DisplayName[0]),
-			SourceID:    types.StringValue(node.SourceID[0]),
-			Type:        types.StringValue(node.Type[0]),
+		nodeProperties, diags := newNodesProperties(node)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
 		}
 		NodeProperties = append(NodeProperties, nodeProperties)
 	}
@@ -138,3 +236,21 @@ func (d *squaredupNodes) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 }
+
+func newNodesProperties(node GremlinQueryResult) (squaredupNodesProperties, diag.Diagnostics) {
+	properties := make(map[string]attr.Value, len(node.Properties))
+	for key, value := range node.Properties {
+		properties[key] = types.StringValue(propertyString(value))
+	}
+
+	propertiesValue, diags := types.MapValue(types.StringType, properties)
+
+	return squaredupNodesProperties{
+		ID:          types.StringValue(node.ID),
+		SourceName:  types.StringValue(firstValue(node.SourceName)),
+		DisplayName: types.StringValue(firstValue(node.DisplayName)),
+		SourceID:    types.StringValue(firstValue(node.SourceID)),
+		Type:        types.StringValue(firstValue(node.Type)),
+		Properties:  propertiesValue,
+	}, diags
+}
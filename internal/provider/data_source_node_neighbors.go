@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &squaredupNodeNeighbors{}
+	_ datasource.DataSourceWithConfigure = &squaredupNodeNeighbors{}
+)
+
+func SquaredUpNodeNeighbors() datasource.DataSource {
+	return &squaredupNodeNeighbors{}
+}
+
+type squaredupNodeNeighbors struct {
+	client *SquaredUpClient
+}
+
+type squaredupNodeNeighborsModel struct {
+	NodeID     types.String               `tfsdk:"node_id"`
+	Direction  types.String               `tfsdk:"direction"`
+	EdgeLabels []string                   `tfsdk:"edge_labels"`
+	Depth      types.Int64                `tfsdk:"depth"`
+	Limit      types.Int64                `tfsdk:"limit"`
+	Nodes      []squaredupNodesProperties `tfsdk:"nodes"`
+	Edges      []squaredupNodeEdge        `tfsdk:"edges"`
+}
+
+type squaredupNodeEdge struct {
+	ID         types.String `tfsdk:"id"`
+	Label      types.String `tfsdk:"label"`
+	FromNodeID types.String `tfsdk:"from_node_id"`
+	ToNodeID   types.String `tfsdk:"to_node_id"`
+}
+
+func (d *squaredupNodeNeighbors) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_node_neighbors"
+}
+
+func (d *squaredupNodeNeighbors) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Walks the edges of the SquaredUp graph starting from a node",
+		Attributes: map[string]schema.Attribute{
+			"node_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the node to start from, for example from `squaredup_nodes`",
+				Required:            true,
+			},
+			"direction": schema.StringAttribute{
+				MarkdownDescription: "Direction of the edges to follow. Must be one of `out`, `in` or `both`. Defaults to `both`.",
+				Optional:            true,
+				Validators:          []validator.String{stringvalidator.OneOf("out", "in", "both")},
+			},
+			"edge_labels": schema.ListAttribute{
+				MarkdownDescription: "Only follow edges with one of these labels, for example `hosts`",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"depth": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of edges to follow from the starting node. Defaults to 1.",
+				Optional:            true,
+				Validators:          []validator.Int64{int64validator.Between(1, 10)},
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of nodes to return",
+				Optional:            true,
+				Validators:          []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"nodes": schema.ListNestedAttribute{
+				MarkdownDescription: "Nodes connected to the starting node",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id":           schema.StringAttribute{Computed: true},
+						"source_name":  schema.StringAttribute{Computed: true},
+						"display_name": schema.StringAttribute{Computed: true},
+						"source_id":    schema.StringAttribute{Computed: true},
+						"type":         schema.StringAttribute{Computed: true},
+						"properties": schema.MapAttribute{
+							MarkdownDescription: "All properties of the node. Properties with several values are JSON encoded.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+					},
+				},
+			},
+			"edges": schema.ListNestedAttribute{
+				MarkdownDescription: "Edges between the starting node and the returned nodes",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id":           schema.StringAttribute{Computed: true},
+						"label":        schema.StringAttribute{Computed: true},
+						"from_node_id": schema.StringAttribute{Computed: true},
+						"to_node_id":   schema.StringAttribute{Computed: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *squaredupNodeNeighbors) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*SquaredUpClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unable to cast provider data to SquaredUpClient",
+			fmt.Sprintf("Expected *SquaredUpClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *squaredupNodeNeighbors) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state squaredupNodeNeighborsModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	traversal := neighborTraversal{
+		NodeID:     state.NodeID.ValueString(),
+		Direction:  "both",
+		EdgeLabels: state.EdgeLabels,
+		Depth:      1,
+		Limit:      int(state.Limit.ValueInt64()),
+	}
+	if !state.Direction.IsNull() {
+		traversal.Direction = state.Direction.ValueString()
+	}
+	if !state.Depth.IsNull() {
+		traversal.Depth = int(state.Depth.ValueInt64())
+	}
+
+	nodes, edges, err := d.client.GetNodeNeighbors(ctx, traversal)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Retrieve Node Neighbors",
+			err.Error(),
+		)
+		return
+	}
+
+	state.Nodes = []squaredupNodesProperties{}
+	for _, node := range nodes {
+		nodeProperties, diags := newNodesProperties(node)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		state.Nodes = append(state.Nodes, nodeProperties)
+	}
+
+	state.Edges = []squaredupNodeEdge{}
+	for _, edge := range edges {
+		state.Edges = append(state.Edges, squaredupNodeEdge{
+			ID:         types.StringValue(edge.ID),
+			Label:      types.StringValue(edge.Label),
+			FromNodeID: types.StringValue(edge.FromNodeID),
+			ToNodeID:   types.StringValue(edge.ToNodeID),
+		})
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
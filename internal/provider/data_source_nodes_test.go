@@ -33,6 +33,7 @@ data "squaredup_nodes" "acc_common_node" {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttrSet("data.squaredup_nodes.acc_common_node", "node_properties.0.id"),
 					resource.TestCheckResourceAttr("data.squaredup_nodes.acc_common_node", "node_properties.0.display_name", "account-common-lambda"),
+					resource.TestCheckResourceAttr("data.squaredup_nodes.acc_common_node", "node_properties.0.properties.name", "account-common-lambda"),
 				),
 			},
 		},
@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// dynamicToGo converts a Terraform value into the plain Go values produced by
+// encoding/json, so it can be sent to the API.
+func dynamicToGo(ctx context.Context, value attr.Value) (interface{}, error) {
+	tfValue, err := value.ToTerraformValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return terraformToGo(tfValue)
+}
+
+// isFullyKnown reports whether a value, including any nested values, is known.
+func isFullyKnown(ctx context.Context, value attr.Value) bool {
+	tfValue, err := value.ToTerraformValue(ctx)
+	return err == nil && tfValue.IsFullyKnown()
+}
+
+func terraformToGo(value tftypes.Value) (interface{}, error) {
+	if !value.IsKnown() {
+		return nil, fmt.Errorf("value is not known yet")
+	}
+	if value.IsNull() {
+		return nil, nil
+	}
+
+	switch {
+	case value.Type().Is(tftypes.String):
+		var s string
+		err := value.As(&s)
+		return s, err
+	case value.Type().Is(tftypes.Bool):
+		var b bool
+		err := value.As(&b)
+		return b, err
+	case value.Type().Is(tftypes.Number):
+		n := new(big.Float)
+		if err := value.As(&n); err != nil {
+			return nil, err
+		}
+		if n.IsInt() {
+			if i, accuracy := n.Int64(); accuracy == big.Exact {
+				return i, nil
+			}
+		}
+		f, _ := n.Float64()
+		return f, nil
+	case value.Type().Is(tftypes.List{}), value.Type().Is(tftypes.Set{}), value.Type().Is(tftypes.Tuple{}):
+		var elements []tftypes.Value
+		if err := value.As(&elements); err != nil {
+			return nil, err
+		}
+		list := make([]interface{}, 0, len(elements))
+		for _, element := range elements {
+			converted, err := terraformToGo(element)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, converted)
+		}
+		return list, nil
+	case value.Type().Is(tftypes.Map{}), value.Type().Is(tftypes.Object{}):
+		var attributes map[string]tftypes.Value
+		if err := value.As(&attributes); err != nil {
+			return nil, err
+		}
+		object := make(map[string]interface{}, len(attributes))
+		for key, attribute := range attributes {
+			converted, err := terraformToGo(attribute)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+			object[key] = converted
+		}
+		return object, nil
+	}
+
+	return nil, fmt.Errorf("unsupported value type %s", value.Type())
+}
+
+// jsonToAttrValue converts a value decoded by encoding/json, with UseNumber
+// enabled, into a Terraform value. Lists become tuples and objects keep their
+// keys as attribute names, so results of any shape can be stored.
+func jsonToAttrValue(value interface{}) (attr.Value, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	switch v := value.(type) {
+	case nil:
+		return types.StringNull(), diags
+	case string:
+		return types.StringValue(v), diags
+	case bool:
+		return types.BoolValue(v), diags
+	case json.Number:
+		n, _, err := big.ParseFloat(v.String(), 10, 512, big.ToNearestEven)
+		if err != nil {
+			diags.AddError("Unable to Convert Number", err.Error())
+			return nil, diags
+		}
+		return types.NumberValue(n), diags
+	case float64:
+		return types.NumberValue(big.NewFloat(v)), diags
+	case []interface{}:
+		elementTypes := make([]attr.Type, 0, len(v))
+		elements := make([]attr.Value, 0, len(v))
+		for _, element := range v {
+			converted, d := jsonToAttrValue(element)
+			diags.Append(d...)
+			if diags.HasError() {
+				return nil, diags
+			}
+			elementTypes = append(elementTypes, converted.Type(context.Background()))
+			elements = append(elements, converted)
+		}
+		tuple, d := types.TupleValue(elementTypes, elements)
+		diags.Append(d...)
+		return tuple, diags
+	case map[string]interface{}:
+		attributeTypes := make(map[string]attr.Type, len(v))
+		attributes := make(map[string]attr.Value, len(v))
+		for key, element := range v {
+			converted, d := jsonToAttrValue(element)
+			diags.Append(d...)
+			if diags.HasError() {
+				return nil, diags
+			}
+			attributeTypes[key] = converted.Type(context.Background())
+			attributes[key] = converted
+		}
+		object, d := types.ObjectValue(attributeTypes, attributes)
+		diags.Append(d...)
+		return object, diags
+	}
+
+	diags.AddError("Unable to Convert Value", fmt.Sprintf("Unsupported value of type %T", value))
+	return nil, diags
+}
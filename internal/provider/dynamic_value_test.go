@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestJSONToAttrValueRoundTrip(t *testing.T) {
+	decoder := json.NewDecoder(strings.NewReader(`[{"id":"node-1","name":["server"],"count":3,"ratio":0.5,"up":true,"owner":null}]`))
+	decoder.UseNumber()
+	var results []interface{}
+	if err := decoder.Decode(&results); err != nil {
+		t.Fatal(err)
+	}
+
+	value, diags := jsonToAttrValue(results)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	converted, err := dynamicToGo(context.Background(), value)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []interface{}{map[string]interface{}{
+		"id":    "node-1",
+		"name":  []interface{}{"server"},
+		"count": int64(3),
+		"ratio": 0.5,
+		"up":    true,
+		"owner": nil,
+	}}
+	if !reflect.DeepEqual(converted, expected) {
+		t.Fatalf("unexpected value: %#v", converted)
+	}
+}
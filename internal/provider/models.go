@@ -1,6 +1,8 @@
 package provider
 
-import "github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+import (
+	"encoding/json"
+)
 
 type LatestDataSource struct {
 	LambdaName  string `json:"lambdaName"`
@@ -57,13 +59,74 @@ type DataSourceDataStreams struct {
 }
 
 type Dashboard struct {
-	DisplayName   string               `json:"displayName"`
-	LastUpdated   string               `json:"lastUpdated"`
-	WorkspaceID   string               `json:"workspaceId"`
-	ID            string               `json:"id"`
-	Content       jsontypes.Normalized `json:"content"`
-	SchemaVersion string               `json:"schemaVersion"`
-	Timeframe     string               `json:"timeframe,omitempty"`
+	DisplayName   string          `json:"displayName"`
+	LastUpdated   string          `json:"lastUpdated"`
+	WorkspaceID   string          `json:"workspaceId"`
+	ID            string          `json:"id"`
+	Content       json.RawMessage `json:"content"`
+	SchemaVersion string          `json:"schemaVersion"`
+	Timeframe     string          `json:"timeframe,omitempty"`
+}
+
+// DashboardContent is the grid layout compiled from tile blocks.
+type DashboardContent struct {
+	Type     string          `json:"_type"`
+	Contents []DashboardTile `json:"contents"`
+	Columns  int64           `json:"columns"`
+	Version  int             `json:"version"`
+}
+
+type DashboardTile struct {
+	ID     string              `json:"i"`
+	X      int64               `json:"x"`
+	Y      int64               `json:"y"`
+	W      int64               `json:"w"`
+	H      int64               `json:"h"`
+	Config DashboardTileConfig `json:"config"`
+}
+
+type DashboardTileConfig struct {
+	Type          string                      `json:"_type"`
+	BaseTile      string                      `json:"baseTile"`
+	Title         string                      `json:"title"`
+	Description   string                      `json:"description"`
+	DataStream    DashboardTileDataStream     `json:"dataStream"`
+	Scope         *DashboardTileScope         `json:"scope,omitempty"`
+	Visualisation *DashboardTileVisualisation `json:"visualisation,omitempty"`
+	Timeframe     string                      `json:"timeframe,omitempty"`
+	Monitor       *DashboardTileMonitor       `json:"monitor,omitempty"`
+}
+
+type DashboardTileDataStream struct {
+	ID             string `json:"id"`
+	PluginConfigID string `json:"pluginConfigId,omitempty"`
+}
+
+type DashboardTileScope struct {
+	Scope       string                 `json:"scope,omitempty"`
+	Workspace   string                 `json:"workspace,omitempty"`
+	Query       string                 `json:"query,omitempty"`
+	Bindings    map[string]interface{} `json:"bindings,omitempty"`
+	QueryDetail *ScopeQueryDetail      `json:"queryDetail,omitempty"`
+}
+
+type DashboardTileVisualisation struct {
+	Type string `json:"type"`
+}
+
+type DashboardTileMonitor struct {
+	Type        string                        `json:"_type"`
+	TileRollsUp bool                          `json:"tileRollsUp"`
+	MonitorType string                        `json:"monitorType"`
+	Frequency   int64                         `json:"frequency"`
+	Aggregation string                        `json:"aggregation"`
+	Column      string                        `json:"column"`
+	Condition   DashboardTileMonitorCondition `json:"condition"`
+}
+
+type DashboardTileMonitorCondition struct {
+	Columns []string               `json:"columns"`
+	Logic   map[string]interface{} `json:"logic"`
 }
 
 type SquaredupGremlinQuery struct {
@@ -76,6 +139,30 @@ type GremlinQueryResult struct {
 	DisplayName []string `json:"name"`
 	SourceID    []string `json:"sourceId"`
 	Type        []string `json:"type"`
+	// Properties holds every property returned by valueMap, keyed by name
+	Properties map[string]interface{} `json:"-"`
+}
+
+func (r *GremlinQueryResult) UnmarshalJSON(data []byte) error {
+	type gremlinQueryResult GremlinQueryResult
+	result := gremlinQueryResult{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(data, &result.Properties); err != nil {
+		return err
+	}
+
+	*r = GremlinQueryResult(result)
+	return nil
+}
+
+type GremlinEdge struct {
+	ID         string `json:"id"`
+	Label      string `json:"label"`
+	FromNodeID string `json:"outV"`
+	ToNodeID   string `json:"inV"`
 }
 
 type DashboardShare struct {
@@ -211,6 +298,14 @@ type DashboardVariable struct {
 	DashboardID            string `json:"dashboardId,omitempty"`
 }
 
+// DashboardVariableDetach is the update payload that unbinds a variable from
+// its dashboard. A missing dashboardId leaves the binding as it is, so it is
+// sent as null.
+type DashboardVariableDetach struct {
+	DashboardVariable
+	DashboardID *string `json:"dashboardId"`
+}
+
 type DashboardVariableRead struct {
 	WorkspaceID string            `json:"workspaceId"`
 	Content     DashboardVariable `json:"content"`
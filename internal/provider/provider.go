@@ -2,9 +2,13 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"regexp"
+	"strconv"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -33,8 +37,29 @@ type squaredupProvider struct {
 }
 
 type squaredupProviderModel struct {
-	Region types.String `tfsdk:"region"`
-	APIKey types.String `tfsdk:"api_key"`
+	Region                    types.String `tfsdk:"region"`
+	APIKey                    types.String `tfsdk:"api_key"`
+	AuthTransport             types.String `tfsdk:"auth_transport"`
+	MaxRetries                types.Int64  `tfsdk:"max_retries"`
+	RetryMaxWait              types.Int64  `tfsdk:"retry_max_wait"`
+	HTTPProxy                 types.String `tfsdk:"http_proxy"`
+	CACertPEM                 types.String `tfsdk:"ca_cert_pem"`
+	CACertFile                types.String `tfsdk:"ca_cert_file"`
+	RequestTimeout            types.Int64  `tfsdk:"request_timeout"`
+	AllowInsecureHTTP         types.Bool   `tfsdk:"allow_insecure_http"`
+	SkipCredentialsValidation types.Bool   `tfsdk:"skip_credentials_validation"`
+	MaxConcurrentRequests     types.Int64  `tfsdk:"max_concurrent_requests"`
+	RequestsPerSecond         types.Int64  `tfsdk:"requests_per_second"`
+	StrictBindings            types.Bool   `tfsdk:"strict_bindings"`
+}
+
+// squaredupProviderData is passed to resources when the provider is
+// configured. It holds the API client together with provider settings that
+// are not about talking to the API, such as resource defaults.
+type squaredupProviderData struct {
+	Client *SquaredUpClient
+	// StrictBindings is the default for strict_bindings on squaredup_dashboard
+	StrictBindings bool
 }
 
 func (p *squaredupProvider) Metadata(_ context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -49,13 +74,68 @@ func (p *squaredupProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 			"region": schema.StringAttribute{
 				MarkdownDescription: "Region of your SquaredUp instance. May also be set via the SQUAREDUP_REGION environment variable.",
 				Optional:            true,
-				Validators:          []validator.String{stringvalidator.RegexMatches(regexp.MustCompile(`^(us|eu|https://.*)$`), "Invalid region format. It must be either 'us', 'eu', or start with 'https://'")},
+				Validators:          []validator.String{stringvalidator.RegexMatches(regexp.MustCompile(`^(us|eu|https?://.*)$`), "Invalid region format. It must be either 'us', 'eu', or start with 'https://' ('http://' requires allow_insecure_http)")},
 			},
 			"api_key": schema.StringAttribute{
 				MarkdownDescription: "API Key for SquaredUp API. May also be set via the SQUAREDUP_API_KEY environment variable.",
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"auth_transport": schema.StringAttribute{
+				MarkdownDescription: "How the API key is sent to SquaredUp. `query` (default) sends it as the `apiKey` query parameter, `header` sends it in an `Authorization: Bearer` header instead, which keeps it out of request URLs and proxy logs. Only use `header` with a SquaredUp API that accepts bearer authentication. May also be set via the SQUAREDUP_AUTH_TRANSPORT environment variable.",
+				Optional:            true,
+				Validators:          []validator.String{stringvalidator.OneOf(authTransportHeader, authTransportQuery)},
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of times a request is retried after a throttling (429) or server (5xx) response. Non-idempotent requests are only retried when the API confirms they were not processed. Defaults to 4. May also be set via the SQUAREDUP_MAX_RETRIES environment variable.",
+				Optional:            true,
+				Validators:          []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"retry_max_wait": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of seconds to wait between retries, including waits requested through the Retry-After header. Defaults to 30. May also be set via the SQUAREDUP_RETRY_MAX_WAIT environment variable.",
+				Optional:            true,
+				Validators:          []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"http_proxy": schema.StringAttribute{
+				MarkdownDescription: "URL of the proxy used to reach the SquaredUp API. Defaults to the standard HTTPS_PROXY and NO_PROXY environment variables. May also be set via the SQUAREDUP_HTTP_PROXY environment variable.",
+				Optional:            true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded certificate authority bundle trusted in addition to the system roots, for example the certificate of a TLS-intercepting proxy. May also be set via the SQUAREDUP_CA_CERT_PEM environment variable.",
+				Optional:            true,
+				Validators:          []validator.String{stringvalidator.ConflictsWith(path.MatchRoot("ca_cert_file"))},
+			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a PEM encoded certificate authority bundle trusted in addition to the system roots. May also be set via the SQUAREDUP_CA_CERT_FILE environment variable.",
+				Optional:            true,
+			},
+			"request_timeout": schema.Int64Attribute{
+				MarkdownDescription: "Timeout in seconds for a single request to the SquaredUp API. Defaults to 120. May also be set via the SQUAREDUP_REQUEST_TIMEOUT environment variable.",
+				Optional:            true,
+				Validators:          []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"allow_insecure_http": schema.BoolAttribute{
+				MarkdownDescription: "Allow `region` to be an unencrypted `http://` URL, for example a local test stand-in. Defaults to false. May also be set via the SQUAREDUP_ALLOW_INSECURE_HTTP environment variable.",
+				Optional:            true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of requests sent to the SquaredUp API at the same time, shared by all resources and data sources. Defaults to 0 (unlimited). May also be set via the SQUAREDUP_MAX_CONCURRENT_REQUESTS environment variable.",
+				Optional:            true,
+				Validators:          []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"requests_per_second": schema.Int64Attribute{
+				MarkdownDescription: "Maximum rate at which requests are sent to the SquaredUp API, shared by all resources and data sources. Defaults to 0 (unlimited). May also be set via the SQUAREDUP_REQUESTS_PER_SECOND environment variable.",
+				Optional:            true,
+				Validators:          []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"strict_bindings": schema.BoolAttribute{
+				MarkdownDescription: "Default for `strict_bindings` on `squaredup_dashboard`. When true, rendering a dashboard template fails if it references a key that is not in the template bindings. Defaults to false. May also be set via the SQUAREDUP_STRICT_BINDINGS environment variable.",
+				Optional:            true,
+			},
+			"skip_credentials_validation": schema.BoolAttribute{
+				MarkdownDescription: "Skip the API call that checks the api key and region when the provider is configured. Invalid credentials are then reported by the first resource or data source that calls the API. Defaults to false. May also be set via the SQUAREDUP_SKIP_CREDENTIALS_VALIDATION environment variable.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -93,6 +173,10 @@ func (p *squaredupProvider) Configure(ctx context.Context, req provider.Configur
 
 	region := os.Getenv("SQUAREDUP_REGION")
 	apiKey := os.Getenv("SQUAREDUP_API_KEY")
+	authTransport := os.Getenv("SQUAREDUP_AUTH_TRANSPORT")
+	httpProxy := os.Getenv("SQUAREDUP_HTTP_PROXY")
+	caCertPEM := os.Getenv("SQUAREDUP_CA_CERT_PEM")
+	caCertFile := os.Getenv("SQUAREDUP_CA_CERT_FILE")
 
 	if !config.Region.IsNull() {
 		region = config.Region.ValueString()
@@ -102,6 +186,96 @@ func (p *squaredupProvider) Configure(ctx context.Context, req provider.Configur
 		apiKey = config.APIKey.ValueString()
 	}
 
+	if !config.AuthTransport.IsNull() {
+		authTransport = config.AuthTransport.ValueString()
+	}
+
+	if authTransport == "" {
+		authTransport = authTransportQuery
+	} else if authTransport != authTransportHeader && authTransport != authTransportQuery {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("auth_transport"),
+			"Invalid SquaredUp Auth Transport",
+			"The auth transport must be either 'header' or 'query'. Check the configuration or the SQUAREDUP_AUTH_TRANSPORT environment variable. Got: "+authTransport,
+		)
+	}
+
+	maxRetries, err := int64FromEnv("SQUAREDUP_MAX_RETRIES", defaultMaxRetries, 0)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("max_retries"), "Invalid SquaredUp Max Retries", err.Error())
+	}
+	if !config.MaxRetries.IsNull() {
+		maxRetries = config.MaxRetries.ValueInt64()
+	}
+
+	retryMaxWait, err := int64FromEnv("SQUAREDUP_RETRY_MAX_WAIT", int64(defaultRetryMaxWait/time.Second), 1)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("retry_max_wait"), "Invalid SquaredUp Retry Max Wait", err.Error())
+	}
+	if !config.RetryMaxWait.IsNull() {
+		retryMaxWait = config.RetryMaxWait.ValueInt64()
+	}
+
+	if !config.HTTPProxy.IsNull() {
+		httpProxy = config.HTTPProxy.ValueString()
+	}
+
+	if !config.CACertPEM.IsNull() {
+		caCertPEM = config.CACertPEM.ValueString()
+	}
+
+	if !config.CACertFile.IsNull() {
+		caCertFile = config.CACertFile.ValueString()
+	}
+
+	requestTimeout, err := int64FromEnv("SQUAREDUP_REQUEST_TIMEOUT", int64(defaultRequestTimeout/time.Second), 1)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("request_timeout"), "Invalid SquaredUp Request Timeout", err.Error())
+	}
+	if !config.RequestTimeout.IsNull() {
+		requestTimeout = config.RequestTimeout.ValueInt64()
+	}
+
+	allowInsecureHTTP, err := boolFromEnv("SQUAREDUP_ALLOW_INSECURE_HTTP", false)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("allow_insecure_http"), "Invalid SquaredUp Allow Insecure HTTP", err.Error())
+	}
+	if !config.AllowInsecureHTTP.IsNull() {
+		allowInsecureHTTP = config.AllowInsecureHTTP.ValueBool()
+	}
+
+	skipCredentialsValidation, err := boolFromEnv("SQUAREDUP_SKIP_CREDENTIALS_VALIDATION", false)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("skip_credentials_validation"), "Invalid SquaredUp Skip Credentials Validation", err.Error())
+	}
+	if !config.SkipCredentialsValidation.IsNull() {
+		skipCredentialsValidation = config.SkipCredentialsValidation.ValueBool()
+	}
+
+	maxConcurrentRequests, err := int64FromEnv("SQUAREDUP_MAX_CONCURRENT_REQUESTS", 0, 0)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("max_concurrent_requests"), "Invalid SquaredUp Max Concurrent Requests", err.Error())
+	}
+	if !config.MaxConcurrentRequests.IsNull() {
+		maxConcurrentRequests = config.MaxConcurrentRequests.ValueInt64()
+	}
+
+	requestsPerSecond, err := int64FromEnv("SQUAREDUP_REQUESTS_PER_SECOND", 0, 0)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("requests_per_second"), "Invalid SquaredUp Requests Per Second", err.Error())
+	}
+	if !config.RequestsPerSecond.IsNull() {
+		requestsPerSecond = config.RequestsPerSecond.ValueInt64()
+	}
+
+	strictBindings, err := boolFromEnv("SQUAREDUP_STRICT_BINDINGS", false)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("strict_bindings"), "Invalid SquaredUp Strict Bindings", err.Error())
+	}
+	if !config.StrictBindings.IsNull() {
+		strictBindings = config.StrictBindings.ValueBool()
+	}
+
 	if region == "" {
 		region = "us"
 		resp.Diagnostics.AddAttributeWarning(
@@ -130,7 +304,19 @@ func (p *squaredupProvider) Configure(ctx context.Context, req provider.Configur
 	ctx = tflog.SetField(ctx, "squaredup_api_key", apiKey)
 	tflog.MaskFieldValuesWithFieldKeys(ctx, "squaredup_api_key")
 
-	client, err := NewSquaredUpClient(region, apiKey, p.version)
+	client, err := NewSquaredUpClient(ctx, region, apiKey, p.version, SquaredUpClientOptions{
+		AuthTransport:             authTransport,
+		MaxRetries:                int(maxRetries),
+		RetryMaxWait:              time.Duration(retryMaxWait) * time.Second,
+		HTTPProxy:                 httpProxy,
+		CACertPEM:                 caCertPEM,
+		CACertFile:                caCertFile,
+		RequestTimeout:            time.Duration(requestTimeout) * time.Second,
+		AllowInsecureHTTP:         allowInsecureHTTP,
+		SkipCredentialsValidation: skipCredentialsValidation,
+		MaxConcurrentRequests:     int(maxConcurrentRequests),
+		RequestsPerSecond:         int(requestsPerSecond),
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create SquaredUp API Client",
@@ -142,7 +328,42 @@ func (p *squaredupProvider) Configure(ctx context.Context, req provider.Configur
 	}
 
 	resp.DataSourceData = client
-	resp.ResourceData = client
+	resp.ResourceData = &squaredupProviderData{
+		Client:         client,
+		StrictBindings: strictBindings,
+	}
+}
+
+// int64FromEnv reads an integer setting from the environment, returning
+// fallback when the variable is not set.
+func int64FromEnv(name string, fallback int64, minimum int64) (int64, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback, nil
+	}
+
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || parsed < minimum {
+		return fallback, fmt.Errorf("the %s environment variable must be an integer of at least %d, got: %q", name, minimum, value)
+	}
+
+	return parsed, nil
+}
+
+// boolFromEnv reads a boolean setting from the environment, returning
+// fallback when the variable is not set.
+func boolFromEnv(name string, fallback bool) (bool, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback, nil
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return fallback, fmt.Errorf("the %s environment variable must be a boolean, got: %q", name, value)
+	}
+
+	return parsed, nil
 }
 
 func (p *squaredupProvider) DataSources(_ context.Context) []func() datasource.DataSource {
@@ -151,6 +372,8 @@ func (p *squaredupProvider) DataSources(_ context.Context) []func() datasource.D
 		SquaredUpDataStreams,
 		SquaredUpAlertingChannelTypes,
 		SquaredUpNodes,
+		SquaredUpGraphQuery,
+		SquaredUpNodeNeighbors,
 	}
 }
 
@@ -167,5 +390,6 @@ func (p *squaredupProvider) Resources(_ context.Context) []func() resource.Resou
 		SquaredUpDashboardImageResource,
 		SquaredUpDashboardVariableResource,
 		SquaredUpDashboardOrderingResource,
+		SquaredUpDashboardTileResource,
 	}
 }
@@ -91,17 +91,17 @@ func (r *AlertingChannelResource) Configure(_ context.Context, req resource.Conf
 		return
 	}
 
-	client, ok := req.ProviderData.(*SquaredUpClient)
+	providerData, ok := req.ProviderData.(*squaredupProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected type for provider data",
-			fmt.Sprintf("Expected *SquaredUpClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *squaredupProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
 }
 
 func (r *AlertingChannelResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -126,7 +126,7 @@ func (r *AlertingChannelResource) Create(ctx context.Context, req resource.Creat
 		Enabled:       plan.Enabled.ValueBool(),
 	}
 
-	alertingChannel, err := r.client.CreateAlertingChannel(alertChannel)
+	alertingChannel, err := r.client.CreateAlertingChannel(ctx, alertChannel)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create alerting channel",
@@ -160,8 +160,12 @@ func (r *AlertingChannelResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
-	alertingChannel, err := r.client.GetAlertingChannel(state.ChannelID.ValueString())
+	alertingChannel, err := r.client.GetAlertingChannel(ctx, state.ChannelID.ValueString())
 	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Unable to get alerting channel",
 			err.Error(),
@@ -215,7 +219,7 @@ func (r *AlertingChannelResource) Update(ctx context.Context, req resource.Updat
 		Enabled:       plan.Enabled.ValueBool(),
 	}
 
-	err := r.client.UpdateAlertingChannel(state.ChannelID.ValueString(), alertChannel)
+	err := r.client.UpdateAlertingChannel(ctx, state.ChannelID.ValueString(), alertChannel)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to update alerting channel",
@@ -224,7 +228,7 @@ func (r *AlertingChannelResource) Update(ctx context.Context, req resource.Updat
 		return
 	}
 
-	readAlertChannel, err := r.client.GetAlertingChannel(state.ChannelID.ValueString())
+	readAlertChannel, err := r.client.GetAlertingChannel(ctx, state.ChannelID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to get alerting channel",
@@ -258,7 +262,7 @@ func (r *AlertingChannelResource) Delete(ctx context.Context, req resource.Delet
 		return
 	}
 
-	err := r.client.DeleteAlertingChannel(state.ChannelID.ValueString())
+	err := r.client.DeleteAlertingChannel(ctx, state.ChannelID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to delete alerting channel",
@@ -2,13 +2,14 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"time"
 
-	"github.com/cbroglie/mustache"
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/dynamicvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -19,9 +20,11 @@ import (
 )
 
 var (
-	_ resource.Resource                = &DashboardResource{}
-	_ resource.ResourceWithConfigure   = &DashboardResource{}
-	_ resource.ResourceWithImportState = &DashboardResource{}
+	_ resource.Resource                   = &DashboardResource{}
+	_ resource.ResourceWithConfigure      = &DashboardResource{}
+	_ resource.ResourceWithImportState    = &DashboardResource{}
+	_ resource.ResourceWithModifyPlan     = &DashboardResource{}
+	_ resource.ResourceWithValidateConfig = &DashboardResource{}
 )
 
 func SquaredUpDashboardResource() resource.Resource {
@@ -30,6 +33,9 @@ func SquaredUpDashboardResource() resource.Resource {
 
 type DashboardResource struct {
 	client *SquaredUpClient
+	// strictBindings is the provider default for strict_bindings. It is null
+	// until the provider is configured.
+	strictBindings types.Bool
 }
 
 type squaredupDashboard struct {
@@ -38,11 +44,17 @@ type squaredupDashboard struct {
 	WorkspaceID       types.String         `tfsdk:"workspace_id"`
 	DashboardTemplate types.String         `tfsdk:"dashboard_template"`
 	DashboardVariable types.String         `tfsdk:"dashboard_variable_id"`
+	DashboardVars     types.Set            `tfsdk:"dashboard_variable_ids"`
 	TemplateBindings  jsontypes.Normalized `tfsdk:"template_bindings"`
+	Bindings          types.Dynamic        `tfsdk:"bindings"`
+	StrictBindings    types.Bool           `tfsdk:"strict_bindings"`
+	TemplateEngine    types.String         `tfsdk:"template_engine"`
 	DashboardContent  jsontypes.Normalized `tfsdk:"dashboard_content"`
 	Timeframe         types.String         `tfsdk:"timeframe"`
 	SchemaVersion     types.String         `tfsdk:"schema_version"`
 	LastUpdated       types.String         `tfsdk:"last_updated"`
+	Tiles             types.List           `tfsdk:"tile"`
+	IgnoreExternal    types.Bool           `tfsdk:"ignore_external_tiles"`
 }
 
 func (r *DashboardResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -50,6 +62,13 @@ func (r *DashboardResource) Metadata(_ context.Context, req resource.MetadataReq
 }
 
 func (r *DashboardResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	tileAttributes := dashboardTileAttributes(true)
+	tileAttributes["id"] = schema.StringAttribute{
+		MarkdownDescription: "The ID of the tile, for use in `squaredup_workspace_alert` and `squaredup_dashboard_image`. Generated from the title when not set, and kept when the tile is moved or renamed.",
+		Optional:            true,
+		Computed:            true,
+	}
+
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Dashboard are used to visualize data from Data Sources",
 		Attributes: map[string]schema.Attribute{
@@ -69,22 +88,44 @@ func (r *DashboardResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Required:            true,
 			},
 			"dashboard_template": schema.StringAttribute{
-				MarkdownDescription: "Dashboard template to use for the dashboard",
-				Required:            true,
+				MarkdownDescription: "Dashboard template to use for the dashboard. Exactly one of `dashboard_template` or `tile` blocks must be set. The rendered content is checked during plan against the JSON schema for the dashboard `schema_version`; properties the schema does not know are reported as warnings.",
+				Optional:            true,
 			},
 			"dashboard_variable_id": schema.StringAttribute{
-				MarkdownDescription: "ID of the dashboard variable to use for this dashboard",
+				MarkdownDescription: "ID of the dashboard variable to use for this dashboard. Prefer `dashboard_variable_ids` for new configurations.",
 				Optional:            true,
 				Computed:            true,
+				Validators:          []validator.String{stringvalidator.ConflictsWith(path.MatchRoot("dashboard_variable_ids"))},
+			},
+			"dashboard_variable_ids": schema.SetAttribute{
+				MarkdownDescription: "IDs of the dashboard variables to use for this dashboard. Variables removed from the set are detached from the dashboard. Conflicts with `dashboard_variable_id`.",
+				Optional:            true,
+				ElementType:         types.StringType,
 			},
 			"template_bindings": schema.StringAttribute{
-				MarkdownDescription: "Template Bindings used for replacing mustache template in the dashboard template. Needs to be a JSON encoded string.",
+				MarkdownDescription: "Template Bindings used for replacing mustache template in the dashboard template. Needs to be a JSON encoded string. Prefer `bindings` for new configurations.",
 				Optional:            true,
 				Computed:            true,
 				CustomType:          jsontypes.NormalizedType{},
 			},
+			"bindings": schema.DynamicAttribute{
+				MarkdownDescription: "Values used to render the dashboard template, as an HCL object. Values may be strings, numbers, booleans, lists or nested objects. Conflicts with `template_bindings`.",
+				Optional:            true,
+				Validators:          []validator.Dynamic{dynamicvalidator.ConflictsWith(path.MatchRoot("template_bindings"))},
+			},
+			"strict_bindings": schema.BoolAttribute{
+				MarkdownDescription: "When true, fail if the dashboard template references a key that is not in the template bindings, and warn about bindings the template never uses. A mustache template without bindings, such as the content of an imported dashboard, is used as is and not checked. Defaults to the provider `strict_bindings` setting.",
+				Optional:            true,
+			},
+			"template_engine": schema.StringAttribute{
+				MarkdownDescription: "Template engine used to render the dashboard template. Either `mustache` (default) or `gotemplate`. " +
+					"`gotemplate` uses Go [text/template](https://pkg.go.dev/text/template) syntax with the bindings as `.`, and adds the functions " +
+					"`toJson` (JSON encode a value), `uuid` (a stable UUID derived from its arguments, for tile IDs) and `default` (use a fallback for empty values).",
+				Optional:   true,
+				Validators: []validator.String{stringvalidator.OneOf(templateEngineMustache, templateEngineGoTemplate)},
+			},
 			"dashboard_content": schema.StringAttribute{
-				MarkdownDescription: "The content of the dashboard. This is the rendered dashboard template with the template bindings applied.",
+				MarkdownDescription: "The content of the dashboard. This is the rendered dashboard template with the template bindings applied, and is shown in the plan whenever both are known. Changes made to the dashboard outside of Terraform are shown here and are reverted by the next apply.",
 				Computed:            true,
 				CustomType:          jsontypes.NormalizedType{},
 			},
@@ -92,30 +133,30 @@ func (r *DashboardResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				MarkdownDescription: "The timeframe of the dashboard. It should be one of the following: last1hour, last12hours, last24hours, last7days, last30days, thisMonth, thisQuarter, thisYear, lastMonth, lastQuarter, lastYear",
 				Optional:            true,
 				Computed:            true,
-				Validators: []validator.String{stringvalidator.OneOf(
-					"last1hour",
-					"last12hours",
-					"last24hours",
-					"last7days",
-					"last30days",
-					"thisMonth",
-					"thisQuarter",
-					"thisYear",
-					"lastMonth",
-					"lastQuarter",
-					"lastYear",
-				)},
+				Validators:          []validator.String{stringvalidator.OneOf(dashboardTimeframes...)},
 			},
 			"schema_version": schema.StringAttribute{
 				MarkdownDescription: "The schema version of the dashboard",
 				Optional:            true,
 				Computed:            true,
 			},
+			"ignore_external_tiles": schema.BoolAttribute{
+				MarkdownDescription: "When true, tiles on the dashboard that are not part of the dashboard content, such as tiles managed by `squaredup_dashboard_tile`, are kept on update and not reported as changes. Defaults to false.",
+				Optional:            true,
+			},
 			"last_updated": schema.StringAttribute{
 				MarkdownDescription: "The last updated date of the dashboard",
 				Computed:            true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"tile": schema.ListNestedBlock{
+				MarkdownDescription: "Data stream tiles that make up the dashboard, as an alternative to `dashboard_template`. The tiles are compiled into the dashboard content.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: tileAttributes,
+				},
+			},
+		},
 	}
 }
 
@@ -124,17 +165,18 @@ func (r *DashboardResource) Configure(_ context.Context, req resource.ConfigureR
 		return
 	}
 
-	client, ok := req.ProviderData.(*SquaredUpClient)
+	providerData, ok := req.ProviderData.(*squaredupProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *SquaredUpClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *squaredupProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
+	r.strictBindings = types.BoolValue(providerData.StrictBindings)
 }
 
 func (r *DashboardResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -144,40 +186,17 @@ func (r *DashboardResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	var templateBindings map[string]interface{}
-	var updatedDashboard string
-
-	if plan.TemplateBindings.ValueString() != "" {
-		diags = plan.TemplateBindings.Unmarshal(&templateBindings)
-		if diags.HasError() {
-			return
-		}
-
-		updatedTemplate, err := mustache.Render(plan.DashboardTemplate.ValueString(), templateBindings)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Unable to render template",
-				err.Error(),
-			)
-			return
-		}
-		// Check if the rendered template is valid JSON
-		var jsonData interface{}
-		err = json.Unmarshal([]byte(updatedTemplate), &jsonData)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Rendered template is not a valid JSON. Please check that the JSON is valid after rendering the template with the template bindings.",
-				err.Error(),
-			)
-			return
-		}
-		updatedDashboard = updatedTemplate
-	} else {
-		updatedDashboard = plan.DashboardTemplate.ValueString()
+	// Warnings were already reported when the change was planned
+	updatedDashboard, _, diags := r.dashboardContent(ctx, plan)
+	resp.Diagnostics.Append(diags.Errors()...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.TemplateBindings.ValueString() == "" {
 		plan.TemplateBindings = jsontypes.NewNormalizedNull()
 	}
 
-	dashboard, err := r.client.CreateDashboard(plan.DisplayName.ValueString(), plan.WorkspaceID.ValueString(), plan.Timeframe.ValueString(), updatedDashboard)
+	dashboard, err := r.client.CreateDashboard(ctx, plan.DisplayName.ValueString(), plan.WorkspaceID.ValueString(), plan.Timeframe.ValueString(), updatedDashboard)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create dashboard",
@@ -188,7 +207,7 @@ func (r *DashboardResource) Create(ctx context.Context, req resource.CreateReque
 
 	var dashboardVariableID string
 	if plan.DashboardVariable.ValueString() != "" {
-		dashboardVariableID, err = UpdateDashboardVariable(r, dashboard.ID, plan.DashboardVariable.ValueString())
+		dashboardVariableID, err = UpdateDashboardVariable(ctx, r, dashboard.ID, plan.DashboardVariable.ValueString())
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Unable to update dashboard variable",
@@ -198,12 +217,23 @@ func (r *DashboardResource) Create(ctx context.Context, req resource.CreateReque
 		}
 	}
 
+	resp.Diagnostics.Append(r.attachDashboardVariables(ctx, dashboard.ID, plan.DashboardVars, types.SetNull(types.StringType))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	state := squaredupDashboard{
 		DashboardID:       types.StringValue(dashboard.ID),
 		DisplayName:       types.StringValue(dashboard.DisplayName),
 		WorkspaceID:       types.StringValue(dashboard.WorkspaceID),
 		DashboardTemplate: plan.DashboardTemplate,
 		TemplateBindings:  plan.TemplateBindings,
+		Bindings:          plan.Bindings,
+		StrictBindings:    plan.StrictBindings,
+		TemplateEngine:    plan.TemplateEngine,
+		Tiles:             plan.Tiles,
+		IgnoreExternal:    plan.IgnoreExternal,
+		DashboardVars:     plan.DashboardVars,
 		DashboardContent:  jsontypes.NewNormalizedValue(updatedDashboard),
 		Timeframe:         types.StringValue(dashboard.Timeframe),
 		SchemaVersion:     types.StringValue(dashboard.SchemaVersion),
@@ -232,8 +262,12 @@ func (r *DashboardResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	dashboard, err := r.client.GetDashboard(state.DashboardID.ValueString())
+	dashboard, err := r.client.GetDashboard(ctx, state.DashboardID.ValueString())
 	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Unable to get dashboard",
 			err.Error(),
@@ -241,32 +275,95 @@ func (r *DashboardResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	// An imported dashboard has no template yet. The server content becomes
+	// the template, without bindings, so the next plan shows no changes.
+	if state.DashboardTemplate.IsNull() && !hasDashboardTiles(state.Tiles) && len(dashboard.Content) > 0 {
+		template, err := importedDashboardTemplate(dashboard.Content)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to read dashboard content",
+				err.Error(),
+			)
+			return
+		}
+		state.DashboardTemplate = types.StringValue(template)
+		state.DashboardContent = jsontypes.NewNormalizedValue(template)
+		state.TemplateBindings = jsontypes.NewNormalizedNull()
+	}
+
+	// Terraform sends an empty list when there are no tile blocks
+	if state.Tiles.IsNull() {
+		state.Tiles = types.ListValueMust(types.ObjectType{AttrTypes: dashboardTileAttrTypes}, []attr.Value{})
+	}
+
+	serverContent := dashboard.Content
+	if state.IgnoreExternal.ValueBool() && !state.DashboardContent.IsNull() {
+		serverContent, err = withoutExternalTiles(state.DashboardContent.ValueString(), serverContent)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to read dashboard content",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	dashboardContent, err := reconcileDashboardContent(state.DashboardContent, serverContent)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read dashboard content",
+			err.Error(),
+		)
+		return
+	}
+
 	state = squaredupDashboard{
 		DashboardID:       types.StringValue(dashboard.ID),
 		DisplayName:       types.StringValue(dashboard.DisplayName),
 		WorkspaceID:       types.StringValue(dashboard.WorkspaceID),
 		DashboardTemplate: state.DashboardTemplate,
 		TemplateBindings:  state.TemplateBindings,
-		DashboardContent:  state.DashboardContent,
+		Bindings:          state.Bindings,
+		StrictBindings:    state.StrictBindings,
+		TemplateEngine:    state.TemplateEngine,
+		Tiles:             state.Tiles,
+		IgnoreExternal:    state.IgnoreExternal,
+		DashboardVars:     state.DashboardVars,
+		DashboardContent:  dashboardContent,
 		Timeframe:         types.StringValue(dashboard.Timeframe),
 		SchemaVersion:     types.StringValue(dashboard.SchemaVersion),
 	}
 
 	// Check if the dashboard variable ID is set
 	if state.DashboardVariable.ValueString() != "" {
-		dashboardVariable, err := r.client.GetDashboardVariable(state.DashboardVariable.ValueString())
-		if err != nil {
+		dashboardVariable, err := r.client.GetDashboardVariable(ctx, state.DashboardVariable.ValueString())
+		if err != nil && !isNotFound(err) {
 			resp.Diagnostics.AddError(
 				"Unable to get dashboard variable",
 				err.Error(),
 			)
 			return
 		}
-		state.DashboardVariable = types.StringValue(dashboardVariable.ID)
+		// A deleted variable is dropped so that the plan binds it again
+		if err != nil {
+			state.DashboardVariable = types.StringNull()
+		} else {
+			state.DashboardVariable = types.StringValue(dashboardVariable.ID)
+		}
 	} else {
 		state.DashboardVariable = types.StringNull()
 	}
 
+	dashboardVariables, err := r.boundDashboardVariables(ctx, dashboard.WorkspaceID, dashboard.ID, state.DashboardVars)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to get dashboard variable",
+			err.Error(),
+		)
+		return
+	}
+	state.DashboardVars = dashboardVariables
+
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -282,40 +379,27 @@ func (r *DashboardResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
-	var templateBindings map[string]interface{}
-	var updatedDashboard string
-
-	if plan.TemplateBindings.ValueString() != "" {
-		diags = plan.TemplateBindings.Unmarshal(&templateBindings)
-		if diags.HasError() {
-			return
-		}
-
-		updatedTemplate, err := mustache.Render(plan.DashboardTemplate.ValueString(), templateBindings)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Unable to render template",
-				err.Error(),
-			)
-			return
-		}
-		// Check if the rendered template is valid JSON
-		var jsonData interface{}
-		err = json.Unmarshal([]byte(updatedTemplate), &jsonData)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Rendered template is not a valid JSON. Please check that the JSON is valid after rendering the template with the template bindings.",
-				err.Error(),
-			)
-			return
-		}
-		updatedDashboard = updatedTemplate
-	} else {
-		updatedDashboard = plan.DashboardTemplate.ValueString()
+	// Warnings were already reported when the change was planned
+	updatedDashboard, _, diags := r.dashboardContent(ctx, plan)
+	resp.Diagnostics.Append(diags.Errors()...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.TemplateBindings.ValueString() == "" {
 		plan.TemplateBindings = jsontypes.NewNormalizedNull()
 	}
 
-	dashboard, err := r.client.UpdateDashboard(plan.DashboardID.ValueString(), plan.DisplayName.ValueString(), plan.Timeframe.ValueString(), updatedDashboard)
+	var dashboard *Dashboard
+	var err error
+	if plan.IgnoreExternal.ValueBool() {
+		dashboard, err = r.client.ModifyDashboard(ctx, plan.DashboardID.ValueString(), func(current *Dashboard) (string, error) {
+			current.DisplayName = plan.DisplayName.ValueString()
+			current.Timeframe = plan.Timeframe.ValueString()
+			return withExternalTiles(updatedDashboard, current.Content)
+		})
+	} else {
+		dashboard, err = r.client.UpdateDashboard(ctx, plan.DashboardID.ValueString(), plan.DisplayName.ValueString(), plan.Timeframe.ValueString(), updatedDashboard)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to update dashboard",
@@ -326,7 +410,7 @@ func (r *DashboardResource) Update(ctx context.Context, req resource.UpdateReque
 
 	var dashboardVariableID string
 	if plan.DashboardVariable.ValueString() != "" {
-		dashboardVariableID, err = UpdateDashboardVariable(r, dashboard.ID, plan.DashboardVariable.ValueString())
+		dashboardVariableID, err = UpdateDashboardVariable(ctx, r, dashboard.ID, plan.DashboardVariable.ValueString())
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Unable to update dashboard variable",
@@ -338,12 +422,29 @@ func (r *DashboardResource) Update(ctx context.Context, req resource.UpdateReque
 		dashboardVariableID = ""
 	}
 
+	var state squaredupDashboard
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.attachDashboardVariables(ctx, dashboard.ID, plan.DashboardVars, state.DashboardVars)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	plan = squaredupDashboard{
 		DashboardID:       types.StringValue(dashboard.ID),
 		DisplayName:       types.StringValue(dashboard.DisplayName),
 		WorkspaceID:       types.StringValue(dashboard.WorkspaceID),
 		DashboardTemplate: plan.DashboardTemplate,
 		TemplateBindings:  plan.TemplateBindings,
+		Bindings:          plan.Bindings,
+		StrictBindings:    plan.StrictBindings,
+		TemplateEngine:    plan.TemplateEngine,
+		Tiles:             plan.Tiles,
+		IgnoreExternal:    plan.IgnoreExternal,
+		DashboardVars:     plan.DashboardVars,
 		DashboardContent:  jsontypes.NewNormalizedValue(updatedDashboard),
 		Timeframe:         types.StringValue(dashboard.Timeframe),
 		SchemaVersion:     types.StringValue(dashboard.SchemaVersion),
@@ -363,6 +464,264 @@ func (r *DashboardResource) Update(ctx context.Context, req resource.UpdateReque
 	}
 }
 
+// ModifyPlan renders the dashboard template, or compiles the tiles, so the
+// plan shows the dashboard content that will be applied. This also plans an update when the content
+// was changed outside of Terraform, which Read records in dashboard_content.
+func (r *DashboardResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var config, plan squaredupDashboard
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Bindings that are not configured are never sent, so there is nothing
+	// to compute after apply
+	if config.TemplateBindings.IsNull() {
+		plan.TemplateBindings = jsontypes.NewNormalizedNull()
+	}
+
+	var state squaredupDashboard
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else {
+		state.Tiles = types.ListNull(types.ObjectType{AttrTypes: dashboardTileAttrTypes})
+	}
+
+	if hasDashboardTiles(plan.Tiles) {
+		resp.Diagnostics.Append(planDashboardTileIDs(ctx, config, &plan, state.Tiles)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	dashboardContent, known, diags := r.dashboardContent(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !known {
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		return
+	}
+
+	if state.DashboardContent.IsNull() || !dashboardContentEqual(state.DashboardContent.ValueString(), dashboardContent) {
+		// Content that is already applied is not checked again, so dashboards
+		// built in the UI can still be imported
+		resp.Diagnostics.Append(r.validateDashboardContent(plan, state, dashboardContent)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		plan.DashboardContent = jsontypes.NewNormalizedValue(dashboardContent)
+	} else {
+		plan.DashboardContent = state.DashboardContent
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// validateDashboardContent checks the content about to be applied against the
+// schema of the dashboard's content format.
+func (r *DashboardResource) validateDashboardContent(plan squaredupDashboard, state squaredupDashboard, content string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	schemaVersion := plan.SchemaVersion
+	if schemaVersion.IsUnknown() {
+		schemaVersion = state.SchemaVersion
+	}
+
+	violations, err := validateDashboardContent(schemaVersion.ValueString(), content)
+	if err != nil {
+		diags.AddError("Unable to validate dashboard content", err.Error())
+		return diags
+	}
+
+	contentPath := path.Root("dashboard_template")
+	if hasDashboardTiles(plan.Tiles) {
+		contentPath = path.Root("tile")
+	}
+	for _, violation := range violations {
+		if violation.Warning {
+			diags.AddAttributeWarning(
+				contentPath,
+				"Unknown dashboard content property",
+				fmt.Sprintf("The rendered dashboard content has a property the dashboard schema does not know at JSON pointer %q: %s. Check it for typos.", violation.Pointer, violation.Message),
+			)
+			continue
+		}
+		diags.AddAttributeError(
+			contentPath,
+			"Invalid dashboard content",
+			fmt.Sprintf("The rendered dashboard content does not match the dashboard schema at JSON pointer %q: %s.", violation.Pointer, violation.Message),
+		)
+	}
+
+	return diags
+}
+
+// ValidateConfig checks that the dashboard is defined by either a template or
+// tile blocks, and that template only settings are not used with tiles.
+func (r *DashboardResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config squaredupDashboard
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hasTemplate := !config.DashboardTemplate.IsNull()
+	hasTiles := hasDashboardTiles(config.Tiles)
+
+	switch {
+	case hasTemplate && hasTiles:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("dashboard_template"),
+			"Conflicting dashboard definition",
+			"A dashboard is defined by either dashboard_template or tile blocks, not both.",
+		)
+		return
+	case !hasTemplate && !hasTiles:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("dashboard_template"),
+			"Missing dashboard definition",
+			"Either dashboard_template or at least one tile block must be set.",
+		)
+		return
+	case hasTemplate:
+		return
+	}
+
+	templateOnly := map[string]attr.Value{
+		"template_bindings": config.TemplateBindings,
+		"bindings":          config.Bindings,
+		"template_engine":   config.TemplateEngine,
+	}
+	for _, name := range []string{"template_bindings", "bindings", "template_engine"} {
+		if !templateOnly[name].IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"Invalid attribute combination",
+				fmt.Sprintf("%s can only be used with dashboard_template, not with tile blocks.", name),
+			)
+		}
+	}
+
+	if config.Tiles.IsUnknown() {
+		return
+	}
+	var tiles []squaredupDashboardTile
+	resp.Diagnostics.Append(config.Tiles.ElementsAs(ctx, &tiles, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for _, problem := range validateDashboardTiles(tiles) {
+		resp.Diagnostics.AddAttributeError(path.Root("tile"), "Invalid tile", problem)
+	}
+}
+
+// dashboardContent returns the content of a dashboard, compiled from its tile
+// blocks or rendered from its template. It returns false when the content is
+// not known yet.
+func (r *DashboardResource) dashboardContent(ctx context.Context, model squaredupDashboard) (string, bool, diag.Diagnostics) {
+	if !hasDashboardTiles(model.Tiles) {
+		tmpl, known, diags := r.dashboardTemplate(ctx, model)
+		if diags.HasError() || !known {
+			return "", known, diags
+		}
+
+		content, renderDiags := tmpl.Render()
+		diags.Append(renderDiags...)
+		return content, true, diags
+	}
+
+	var diags diag.Diagnostics
+	if model.Tiles.IsUnknown() {
+		return "", false, diags
+	}
+
+	var tiles []squaredupDashboardTile
+	diags.Append(model.Tiles.ElementsAs(ctx, &tiles, false)...)
+	if diags.HasError() {
+		return "", false, diags
+	}
+
+	if !isFullyKnown(ctx, model.Tiles) || model.WorkspaceID.IsUnknown() {
+		return "", false, diags
+	}
+
+	content, d := compileDashboardTiles(ctx, model.WorkspaceID.ValueString(), tiles)
+	diags.Append(d...)
+	return content, true, diags
+}
+
+// dashboardTemplate collects the template and bindings of a dashboard. It
+// returns false when they are not known yet.
+func (r *DashboardResource) dashboardTemplate(ctx context.Context, model squaredupDashboard) (dashboardTemplate, bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	strict := r.strictBindingsValue(model.StrictBindings)
+	tmpl := dashboardTemplate{
+		Template: model.DashboardTemplate.ValueString(),
+		Engine:   templateEngineMustache,
+		Strict:   strict.ValueBool(),
+	}
+	if !model.TemplateEngine.IsNull() {
+		tmpl.Engine = model.TemplateEngine.ValueString()
+	}
+	if model.DashboardTemplate.IsUnknown() || model.TemplateEngine.IsUnknown() || strict.IsUnknown() {
+		return tmpl, false, diags
+	}
+
+	switch {
+	case !model.Bindings.IsNull():
+		tmpl.BindingsPath = path.Root("bindings")
+		if !isFullyKnown(ctx, model.Bindings) {
+			return tmpl, false, diags
+		}
+
+		value, err := dynamicToGo(ctx, model.Bindings)
+		if err != nil {
+			diags.AddAttributeError(tmpl.BindingsPath, "Invalid Bindings", err.Error())
+			return tmpl, false, diags
+		}
+
+		bindings, ok := value.(map[string]interface{})
+		if !ok {
+			diags.AddAttributeError(tmpl.BindingsPath, "Invalid Bindings", "Bindings must be an object of named values")
+			return tmpl, false, diags
+		}
+		tmpl.Bindings = bindings
+	case model.TemplateBindings.IsUnknown():
+		return tmpl, false, diags
+	default:
+		tmpl.BindingsPath = path.Root("template_bindings")
+		if model.TemplateBindings.ValueString() != "" {
+			diags.Append(model.TemplateBindings.Unmarshal(&tmpl.Bindings)...)
+		}
+	}
+
+	return tmpl, true, diags
+}
+
+// strictBindingsValue returns whether missing template bindings are an
+// error, falling back to the provider default. The result is unknown when
+// value or the provider configuration is not known yet.
+func (r *DashboardResource) strictBindingsValue(value types.Bool) types.Bool {
+	if !value.IsNull() {
+		return value
+	}
+	if r.strictBindings.IsNull() {
+		return types.BoolUnknown()
+	}
+	return r.strictBindings
+}
+
 func (r *DashboardResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var state squaredupDashboard
 	diags := req.State.Get(ctx, &state)
@@ -371,7 +730,7 @@ func (r *DashboardResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
-	err := r.client.DeleteDashboard(state.DashboardID.ValueString())
+	err := r.client.DeleteDashboard(ctx, state.DashboardID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to delete dashboard",
@@ -385,8 +744,8 @@ func (r *DashboardResource) ImportState(ctx context.Context, req resource.Import
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
-func UpdateDashboardVariable(squaredupProvider *DashboardResource, dashboardID string, variableId string) (string, error) {
-	dashboardVariable, err := squaredupProvider.client.GetDashboardVariable(variableId)
+func UpdateDashboardVariable(ctx context.Context, squaredupProvider *DashboardResource, dashboardID string, variableId string) (string, error) {
+	dashboardVariable, err := squaredupProvider.client.GetDashboardVariable(ctx, variableId)
 	if err != nil {
 		return "", err
 	}
@@ -400,10 +759,120 @@ func UpdateDashboardVariable(squaredupProvider *DashboardResource, dashboardID s
 		DashboardID:            dashboardID,
 	}
 
-	updatedDashboardVariable, err := squaredupProvider.client.UpdateDashboardVariable(dashboardVariable.ID, updateRequestBody)
+	updatedDashboardVariable, err := squaredupProvider.client.UpdateDashboardVariable(ctx, dashboardVariable.ID, updateRequestBody)
 	if err != nil {
 		return "", err
 	}
 
 	return updatedDashboardVariable.ID, nil
 }
+
+// attachDashboardVariables binds the variables added to planned since prior
+// to the dashboard and detaches the variables that were removed.
+func (r *DashboardResource) attachDashboardVariables(ctx context.Context, dashboardID string, planned types.Set, prior types.Set) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var plannedIDs, priorIDs []string
+	if !planned.IsNull() {
+		diags.Append(planned.ElementsAs(ctx, &plannedIDs, false)...)
+	}
+	if !prior.IsNull() {
+		diags.Append(prior.ElementsAs(ctx, &priorIDs, false)...)
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	keep := map[string]bool{}
+	for _, variableID := range plannedIDs {
+		keep[variableID] = true
+	}
+	attached := map[string]bool{}
+	for _, variableID := range priorIDs {
+		attached[variableID] = true
+	}
+
+	for _, variableID := range priorIDs {
+		if keep[variableID] {
+			continue
+		}
+		if err := DetachDashboardVariable(ctx, r, dashboardID, variableID); err != nil {
+			diags.AddError(
+				"Unable to detach dashboard variable",
+				fmt.Sprintf("Unable to detach dashboard variable %s: %s", variableID, err),
+			)
+			return diags
+		}
+	}
+
+	for _, variableID := range plannedIDs {
+		if attached[variableID] {
+			continue
+		}
+		if _, err := UpdateDashboardVariable(ctx, r, dashboardID, variableID); err != nil {
+			diags.AddError(
+				"Unable to update dashboard variable",
+				fmt.Sprintf("Unable to attach dashboard variable %s: %s", variableID, err),
+			)
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// boundDashboardVariables returns the variables bound to the dashboard when
+// dashboard_variable_ids is managed, listing the workspace variables once.
+// Variables attached outside of Terraform are included and variables that
+// were deleted or bound to another dashboard are dropped, so the plan shows
+// both.
+func (r *DashboardResource) boundDashboardVariables(ctx context.Context, workspaceID string, dashboardID string, ids types.Set) (types.Set, error) {
+	if ids.IsNull() || ids.IsUnknown() {
+		return ids, nil
+	}
+
+	variables, err := r.client.ListDashboardVariables(ctx, workspaceID)
+	if err != nil {
+		return ids, err
+	}
+
+	bound := []attr.Value{}
+	for _, variable := range variables {
+		if variable.Content.DashboardID == dashboardID {
+			bound = append(bound, types.StringValue(variable.ID))
+		}
+	}
+
+	set, diags := types.SetValue(types.StringType, bound)
+	if diags.HasError() {
+		return ids, fmt.Errorf("unable to build dashboard_variable_ids")
+	}
+	return set, nil
+}
+
+// DetachDashboardVariable removes the binding between a variable and the
+// dashboard. Variables that were deleted or are bound to another dashboard
+// are left as they are.
+func DetachDashboardVariable(ctx context.Context, squaredupProvider *DashboardResource, dashboardID string, variableId string) error {
+	dashboardVariable, err := squaredupProvider.client.GetDashboardVariable(ctx, variableId)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if dashboardVariable.Content.DashboardID != dashboardID {
+		return nil
+	}
+
+	updateRequestBody := DashboardVariable{
+		Name:                   dashboardVariable.Name,
+		Type:                   dashboardVariable.Content.Type,
+		ScopeID:                dashboardVariable.Content.ScopeID,
+		Default:                dashboardVariable.Content.Default,
+		AllowMultipleSelection: dashboardVariable.Content.AllowMultipleSelection,
+	}
+
+	_, err = squaredupProvider.client.DetachDashboardVariable(ctx, dashboardVariable.ID, updateRequestBody)
+	return err
+}
@@ -0,0 +1,578 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/cbroglie/mustache"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+const (
+	templateEngineMustache   = "mustache"
+	templateEngineGoTemplate = "gotemplate"
+)
+
+// dashboardTemplate is a dashboard template together with the bindings used
+// to render it.
+type dashboardTemplate struct {
+	Template string
+	// Engine is either templateEngineMustache or templateEngineGoTemplate
+	Engine string
+	// Bindings is nil when no bindings are configured, a mustache template is
+	// then used as is
+	Bindings map[string]interface{}
+	// BindingsPath is the attribute the bindings were configured in
+	BindingsPath path.Path
+	// Strict requires every key the template references to be bound
+	Strict bool
+}
+
+// Render renders the template and checks that the result is valid JSON.
+func (t dashboardTemplate) Render() (string, diag.Diagnostics) {
+	var rendered string
+	var diags diag.Diagnostics
+
+	if t.Engine == templateEngineGoTemplate {
+		rendered, diags = t.renderGoTemplate()
+	} else {
+		rendered, diags = t.renderMustache()
+	}
+	if diags.HasError() {
+		return "", diags
+	}
+
+	// Check if the rendered template is valid JSON
+	var jsonData interface{}
+	err := json.Unmarshal([]byte(rendered), &jsonData)
+	if err != nil {
+		diags.AddError(
+			"Rendered template is not a valid JSON. Please check that the JSON is valid after rendering the template with the template bindings.",
+			err.Error(),
+		)
+		return "", diags
+	}
+
+	return rendered, diags
+}
+
+func (t dashboardTemplate) renderMustache() (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	// Without bindings the template is used as is, for example the content
+	// of an imported dashboard, so any {{...}} in it is literal text
+	if t.Strict && t.Bindings != nil {
+		missing, unused, err := checkTemplateBindings(t.Template, t.Bindings)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("dashboard_template"),
+				"Unable to parse template",
+				err.Error(),
+			)
+			return "", diags
+		}
+		for _, name := range missing {
+			diags.AddAttributeError(
+				t.BindingsPath,
+				"Missing template binding",
+				fmt.Sprintf("The dashboard template references %q, which is not set in %s.", name, t.BindingsPath),
+			)
+		}
+		t.warnUnusedBindings(&diags, unused)
+		if diags.HasError() {
+			return "", diags
+		}
+	}
+
+	if t.Bindings == nil {
+		return t.Template, diags
+	}
+
+	rendered, err := mustache.Render(t.Template, t.Bindings)
+	if err != nil {
+		diags.AddError(
+			"Unable to render template",
+			err.Error(),
+		)
+		return "", diags
+	}
+
+	return rendered, diags
+}
+
+func (t dashboardTemplate) warnUnusedBindings(diags *diag.Diagnostics, unused []string) {
+	for _, name := range unused {
+		diags.AddAttributeWarning(
+			t.BindingsPath,
+			"Unused template binding",
+			fmt.Sprintf("The template binding %q is not referenced by the dashboard template.", name),
+		)
+	}
+}
+
+// checkTemplateBindings returns the names a mustache template references that
+// cannot be resolved from bindings, and the top level bindings the template
+// never references. Names are resolved the same way mustache renders them,
+// so keys of list items inside a section are not reported.
+func checkTemplateBindings(template string, bindings map[string]interface{}) ([]string, []string, error) {
+	tmpl, err := mustache.ParseString(template)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	check := templateBindingsCheck{
+		used:    map[string]bool{},
+		missing: map[string]bool{},
+	}
+	check.walk(tmpl.Tags(), []interface{}{bindings})
+
+	missing := make([]string, 0, len(check.missing))
+	for name := range check.missing {
+		missing = append(missing, name)
+	}
+	sort.Strings(missing)
+
+	var unused []string
+	for name := range bindings {
+		if !check.used[name] {
+			unused = append(unused, name)
+		}
+	}
+	sort.Strings(unused)
+
+	return missing, unused, nil
+}
+
+type templateBindingsCheck struct {
+	used    map[string]bool
+	missing map[string]bool
+}
+
+// walk resolves the tags against contexts, which holds the innermost context
+// first.
+func (c *templateBindingsCheck) walk(tags []mustache.Tag, contexts []interface{}) {
+	for _, tag := range tags {
+		switch tag.Type() {
+		case mustache.Variable:
+			if _, ok := c.lookup(contexts, tag.Name()); !ok {
+				c.missing[tag.Name()] = true
+			}
+		case mustache.Section, mustache.InvertedSection:
+			value, ok := c.lookup(contexts, tag.Name())
+			if !ok {
+				c.missing[tag.Name()] = true
+				continue
+			}
+			if tag.Type() == mustache.InvertedSection {
+				c.walk(tag.Tags(), contexts)
+				continue
+			}
+			switch v := value.(type) {
+			case []interface{}:
+				for _, item := range v {
+					c.walk(tag.Tags(), append([]interface{}{item}, contexts...))
+				}
+			case map[string]interface{}:
+				c.walk(tag.Tags(), append([]interface{}{v}, contexts...))
+			default:
+				c.walk(tag.Tags(), contexts)
+			}
+		}
+	}
+}
+
+func (c *templateBindingsCheck) lookup(contexts []interface{}, name string) (interface{}, bool) {
+	if name == "." {
+		return contexts[0], true
+	}
+
+	parts := strings.Split(name, ".")
+	for i, context := range contexts {
+		object, ok := context.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		value, ok := object[parts[0]]
+		if !ok {
+			continue
+		}
+		if i == len(contexts)-1 {
+			c.used[parts[0]] = true
+		}
+
+		for _, part := range parts[1:] {
+			object, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+			if value, ok = object[part]; !ok {
+				return nil, false
+			}
+		}
+		return value, true
+	}
+
+	return nil, false
+}
+
+// reconcileDashboardContent compares the dashboard content returned by the API
+// with the content last applied by the provider. Keys the API adds, such as
+// tile metadata, are ignored, as are null values in the applied content, empty
+// values the API drops and the order of tiles. The applied content is kept when both match, otherwise
+// the normalized server content is returned so the next plan updates the
+// dashboard.
+func reconcileDashboardContent(applied jsontypes.Normalized, server json.RawMessage) (jsontypes.Normalized, error) {
+	if len(server) == 0 {
+		return applied, nil
+	}
+
+	var actual interface{}
+	if err := json.Unmarshal(server, &actual); err != nil {
+		return applied, err
+	}
+
+	if applied.IsNull() || applied.IsUnknown() {
+		return jsontypes.NewNormalizedValue(string(server)), nil
+	}
+
+	var desired interface{}
+	if err := json.Unmarshal([]byte(applied.ValueString()), &desired); err != nil {
+		return applied, err
+	}
+
+	desired = pruneDashboardContent(desired)
+	normalized := normalizeDashboardContent(desired, actual)
+	if reflect.DeepEqual(desired, normalized) {
+		return applied, nil
+	}
+
+	content, err := json.Marshal(normalized)
+	if err != nil {
+		return applied, err
+	}
+	return jsontypes.NewNormalizedValue(string(content)), nil
+}
+
+// pruneDashboardContent removes the keys with null values, which the API
+// drops from the content it stores.
+func pruneDashboardContent(content interface{}) interface{} {
+	switch value := content.(type) {
+	case map[string]interface{}:
+		pruned := make(map[string]interface{}, len(value))
+		for key, element := range value {
+			if element != nil {
+				pruned[key] = pruneDashboardContent(element)
+			}
+		}
+		return pruned
+	case []interface{}:
+		pruned := make([]interface{}, len(value))
+		for i, element := range value {
+			pruned[i] = pruneDashboardContent(element)
+		}
+		return pruned
+	}
+
+	return content
+}
+
+func emptyDashboardContent(content interface{}) bool {
+	switch value := content.(type) {
+	case nil:
+		return true
+	case string:
+		return value == ""
+	case map[string]interface{}:
+		return len(value) == 0
+	case []interface{}:
+		return len(value) == 0
+	}
+	return false
+}
+
+// normalizeDashboardContent strips the keys from actual that are not present
+// in desired. Empty values in desired match missing, null or empty values in
+// actual. Tiles are matched by their ID, so reordering them is not a
+// change, and other lists element by element. Elements added outside of
+// Terraform are kept.
+func normalizeDashboardContent(desired interface{}, actual interface{}) interface{} {
+	switch desiredValue := desired.(type) {
+	case map[string]interface{}:
+		actualValue, ok := actual.(map[string]interface{})
+		if !ok {
+			return actual
+		}
+		normalized := make(map[string]interface{}, len(desiredValue))
+		for key, value := range desiredValue {
+			actualElement, ok := actualValue[key]
+			if emptyDashboardContent(value) && emptyDashboardContent(actualElement) {
+				// The API drops or nulls empty values
+				normalized[key] = value
+			} else if ok {
+				normalized[key] = normalizeDashboardContent(value, actualElement)
+			}
+		}
+		return normalized
+	case []interface{}:
+		actualValue, ok := actual.([]interface{})
+		if !ok {
+			return actual
+		}
+		if normalized, ok := normalizeDashboardTiles(desiredValue, actualValue); ok {
+			return normalized
+		}
+		normalized := make([]interface{}, len(actualValue))
+		for i, actualElement := range actualValue {
+			if i < len(desiredValue) {
+				normalized[i] = normalizeDashboardContent(desiredValue[i], actualElement)
+			} else {
+				normalized[i] = actualElement
+			}
+		}
+		return normalized
+	}
+
+	return actual
+}
+
+// normalizeDashboardTiles orders the actual tiles the same as the desired
+// tiles, followed by the tiles that are only in actual. It returns false when
+// the lists are not lists of tiles with unique IDs.
+func normalizeDashboardTiles(desired []interface{}, actual []interface{}) ([]interface{}, bool) {
+	desiredIDs, ok := dashboardTileIndex(desired)
+	if !ok {
+		return nil, false
+	}
+	actualIDs, ok := dashboardTileIndex(actual)
+	if !ok {
+		return nil, false
+	}
+
+	normalized := make([]interface{}, 0, len(actual))
+	for _, element := range desired {
+		id := element.(map[string]interface{})["i"].(string)
+		if i, ok := actualIDs[id]; ok {
+			normalized = append(normalized, normalizeDashboardContent(element, actual[i]))
+		}
+	}
+	for _, element := range actual {
+		if _, ok := desiredIDs[element.(map[string]interface{})["i"].(string)]; !ok {
+			normalized = append(normalized, element)
+		}
+	}
+	return normalized, true
+}
+
+// dashboardTileIndex returns the position of each tile by ID.
+func dashboardTileIndex(tiles []interface{}) (map[string]int, bool) {
+	if len(tiles) == 0 {
+		return nil, false
+	}
+	index := make(map[string]int, len(tiles))
+	for i, element := range tiles {
+		tile, ok := element.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		id, ok := tile["i"].(string)
+		if _, duplicate := index[id]; !ok || duplicate {
+			return nil, false
+		}
+		index[id] = i
+	}
+	return index, true
+}
+
+// importedDashboardTemplate returns the content of an imported dashboard as
+// an indented template, so it can be used in configuration as is.
+func importedDashboardTemplate(server json.RawMessage) (string, error) {
+	var content interface{}
+	if err := json.Unmarshal(server, &content); err != nil {
+		return "", err
+	}
+
+	template, err := json.MarshalIndent(content, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(template), nil
+}
+
+// dashboardContentEqual reports whether two JSON documents are semantically
+// equal.
+func dashboardContentEqual(a string, b string) bool {
+	var aValue, bValue interface{}
+	if json.Unmarshal([]byte(a), &aValue) != nil || json.Unmarshal([]byte(b), &bValue) != nil {
+		return false
+	}
+	return reflect.DeepEqual(aValue, bValue)
+}
+
+// dashboardLayout is grid dashboard content decoded for editing its tiles.
+// Keys the provider does not know about are kept as they are.
+type dashboardLayout map[string]interface{}
+
+func parseDashboardLayout(content []byte) (dashboardLayout, error) {
+	layout := dashboardLayout{}
+	if err := json.Unmarshal(content, &layout); err != nil {
+		return nil, fmt.Errorf("dashboard content is not a JSON object: %w", err)
+	}
+	return layout, nil
+}
+
+func (l dashboardLayout) tiles() []interface{} {
+	tiles, _ := l["contents"].([]interface{})
+	return tiles
+}
+
+// Tile returns the tile with the given ID.
+func (l dashboardLayout) Tile(id string) (map[string]interface{}, bool) {
+	for _, element := range l.tiles() {
+		if tile, ok := element.(map[string]interface{}); ok && tile["i"] == id {
+			return tile, true
+		}
+	}
+	return nil, false
+}
+
+// TileIDs returns the IDs of all tiles in the layout.
+func (l dashboardLayout) TileIDs() map[string]bool {
+	ids := map[string]bool{}
+	for _, element := range l.tiles() {
+		if tile, ok := element.(map[string]interface{}); ok {
+			if id, ok := tile["i"].(string); ok {
+				ids[id] = true
+			}
+		}
+	}
+	return ids
+}
+
+// SetTile replaces the tile with the same ID, keeping the keys of the
+// existing tile that tile does not set, or adds it to the end of the layout.
+// The grid is widened when the tile does not fit.
+func (l dashboardLayout) SetTile(tile map[string]interface{}) {
+	if existing, ok := l.Tile(fmt.Sprint(tile["i"])); ok {
+		for key, value := range tile {
+			existing[key] = value
+		}
+	} else {
+		l["contents"] = append(l.tiles(), tile)
+	}
+
+	if right := layoutNumber(tile["x"]) + layoutNumber(tile["w"]); right > layoutNumber(l["columns"]) {
+		l["columns"] = right
+	}
+}
+
+// RemoveTile removes the tile with the given ID and reports whether it was
+// present.
+func (l dashboardLayout) RemoveTile(id string) bool {
+	tiles := l.tiles()
+	for i, element := range tiles {
+		if tile, ok := element.(map[string]interface{}); ok && tile["i"] == id {
+			l["contents"] = append(tiles[:i:i], tiles[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (l dashboardLayout) String() (string, error) {
+	content, err := json.Marshal(l)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// layoutNumber returns a layout number, such as a tile position, as a
+// float64. Missing values are 0.
+func layoutNumber(value interface{}) float64 {
+	switch v := value.(type) {
+	case float64:
+		return v
+	case int64:
+		return float64(v)
+	case int:
+		return float64(v)
+	case json.Number:
+		f, _ := v.Float64()
+		return f
+	}
+	return 0
+}
+
+// withoutExternalTiles removes the tiles from the server content that are not
+// in the applied content, so that tiles managed elsewhere, for example by
+// squaredup_dashboard_tile, are not reported as drift. The applied column
+// count is kept when external tiles widened the grid.
+func withoutExternalTiles(applied string, server json.RawMessage) (json.RawMessage, error) {
+	if len(server) == 0 {
+		return server, nil
+	}
+
+	desired, err := parseDashboardLayout([]byte(applied))
+	if err != nil {
+		return nil, err
+	}
+	actual, err := parseDashboardLayout(server)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := desired.TileIDs()
+	removed := false
+	for id := range actual.TileIDs() {
+		if !ids[id] {
+			removed = actual.RemoveTile(id) || removed
+		}
+	}
+
+	if columns, ok := desired["columns"]; ok && removed && layoutNumber(actual["columns"]) > layoutNumber(columns) {
+		actual["columns"] = columns
+	}
+
+	content, err := actual.String()
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(content), nil
+}
+
+// withExternalTiles adds the tiles of the server content that are not in the
+// rendered content, so that updating a dashboard keeps tiles managed
+// elsewhere.
+func withExternalTiles(rendered string, server json.RawMessage) (string, error) {
+	if len(server) == 0 {
+		return rendered, nil
+	}
+
+	desired, err := parseDashboardLayout([]byte(rendered))
+	if err != nil {
+		return "", err
+	}
+	actual, err := parseDashboardLayout(server)
+	if err != nil {
+		return "", err
+	}
+
+	ids := desired.TileIDs()
+	for _, element := range actual.tiles() {
+		tile, ok := element.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if id, ok := tile["i"].(string); !ok || ids[id] {
+			continue
+		}
+		desired.SetTile(tile)
+	}
+
+	return desired.String()
+}
@@ -0,0 +1,246 @@
+package provider
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+const appliedDashboardContent = `{"_type":"layout/grid","columns":1,"contents":[{"i":"1","x":0,"y":0,"w":4,"h":2,"config":{"_type":"tile/text","title":"Hello"}}]}`
+
+func TestReconcileDashboardContentIgnoresServerAddedKeys(t *testing.T) {
+	applied := jsontypes.NewNormalizedValue(appliedDashboardContent)
+	server := json.RawMessage(`{"_type":"layout/grid","columns":1,"version":4,"contents":[{"i":"1","x":0,"y":0,"w":4,"h":2,"moved":false,"config":{"_type":"tile/text","title":"Hello","baseTile":"text"}}]}`)
+
+	content, err := reconcileDashboardContent(applied, server)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if content.ValueString() != appliedDashboardContent {
+		t.Fatalf("expected the applied content to be kept, got %s", content.ValueString())
+	}
+}
+
+func TestReconcileDashboardContentDetectsUIEdits(t *testing.T) {
+	applied := jsontypes.NewNormalizedValue(appliedDashboardContent)
+	server := json.RawMessage(`{"_type":"layout/grid","columns":1,"version":4,"contents":[{"i":"1","x":0,"y":0,"w":4,"h":2,"config":{"_type":"tile/text","title":"Edited in the UI"}},{"i":"2","x":0,"y":2,"w":4,"h":2,"config":{"_type":"tile/text","title":"New tile"}}]}`)
+
+	content, err := reconcileDashboardContent(applied, server)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dashboardContentEqual(content.ValueString(), appliedDashboardContent) {
+		t.Fatal("expected the UI edit to be detected")
+	}
+
+	expected := `{"_type":"layout/grid","columns":1,"contents":[{"i":"1","x":0,"y":0,"w":4,"h":2,"config":{"_type":"tile/text","title":"Edited in the UI"}},{"i":"2","x":0,"y":2,"w":4,"h":2,"config":{"_type":"tile/text","title":"New tile"}}]}`
+	if !dashboardContentEqual(content.ValueString(), expected) {
+		t.Fatalf("unexpected normalized content: %s", content.ValueString())
+	}
+}
+
+func TestReconcileDashboardContentIgnoresDroppedKeys(t *testing.T) {
+	applied := jsontypes.NewNormalizedValue(`{"_type":"layout/grid","columns":4,"contents":[{"i":"1","x":0,"y":0,"w":4,"h":2,"config":{"_type":"tile/text","title":"Hello","description":"","timeframe":null,"variables":[]}}]}`)
+	server := json.RawMessage(`{"_type":"layout/grid","columns":4,"contents":[{"i":"1","x":0,"y":0,"w":4,"h":2,"config":{"_type":"tile/text","title":"Hello","description":null}}]}`)
+
+	content, err := reconcileDashboardContent(applied, server)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if content.ValueString() != applied.ValueString() {
+		t.Fatalf("expected the applied content to be kept, got %s", content.ValueString())
+	}
+}
+
+func TestReconcileDashboardContentIgnoresTileOrder(t *testing.T) {
+	applied := jsontypes.NewNormalizedValue(`{"_type":"layout/grid","contents":[{"i":"1","x":0,"y":0,"w":2,"h":2,"config":{"title":"First"}},{"i":"2","x":2,"y":0,"w":2,"h":2,"config":{"title":"Second"}}]}`)
+	server := json.RawMessage(`{"_type":"layout/grid","contents":[{"i":"2","x":2,"y":0,"w":2,"h":2,"config":{"title":"Second"}},{"i":"1","x":0,"y":0,"w":2,"h":2,"config":{"title":"First"}}]}`)
+
+	content, err := reconcileDashboardContent(applied, server)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if content.ValueString() != applied.ValueString() {
+		t.Fatalf("expected the applied content to be kept, got %s", content.ValueString())
+	}
+
+	// Tiles added to an empty dashboard are still detected
+	empty := jsontypes.NewNormalizedValue(`{"_type":"layout/grid","contents":[]}`)
+	content, err = reconcileDashboardContent(empty, server)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if content.ValueString() == empty.ValueString() {
+		t.Fatal("expected the added tiles to be detected")
+	}
+}
+
+func TestReconcileDashboardContentWithoutAppliedContent(t *testing.T) {
+	server := json.RawMessage(`{"_type":"layout/grid","contents":[]}`)
+
+	content, err := reconcileDashboardContent(jsontypes.NewNormalizedNull(), server)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if content.ValueString() != string(server) {
+		t.Fatalf("expected the server content, got %s", content.ValueString())
+	}
+}
+
+func TestCheckTemplateBindings(t *testing.T) {
+	template := `{"title":"{{title}}","scope":"{{scopes.primary}}","tiles":[{{#tiles}}{"name":"{{name}}","env":"{{environment}}"}{{/tiles}}],"typo":"{{scpoe_id}}"}`
+	bindings := map[string]interface{}{
+		"title":       "Service",
+		"scopes":      map[string]interface{}{"primary": "scope-1"},
+		"tiles":       []interface{}{map[string]interface{}{"name": "cpu"}},
+		"environment": "prod",
+		"scope_id":    "scope-2",
+	}
+
+	missing, unused, err := checkTemplateBindings(template, bindings)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(missing, []string{"scpoe_id"}) {
+		t.Fatalf("unexpected missing bindings: %v", missing)
+	}
+	if !reflect.DeepEqual(unused, []string{"scope_id"}) {
+		t.Fatalf("unexpected unused bindings: %v", unused)
+	}
+}
+
+func TestDashboardTemplateRenderStrictBindings(t *testing.T) {
+	tmpl := dashboardTemplate{
+		Template:     `{"title":"{{title}}","scope":"{{scope_id}}"}`,
+		Bindings:     map[string]interface{}{"title": "Service", "scpoe_id": "scope-1"},
+		BindingsPath: path.Root("bindings"),
+	}
+
+	content, diags := tmpl.Render()
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if content != `{"title":"Service","scope":""}` {
+		t.Fatalf("unexpected content: %s", content)
+	}
+
+	tmpl.Strict = true
+	_, diags = tmpl.Render()
+	if diags.ErrorsCount() != 1 || diags.WarningsCount() != 1 {
+		t.Fatalf("expected one missing and one unused binding, got %v", diags)
+	}
+}
+
+func TestDashboardTemplateRenderStrictWithoutBindings(t *testing.T) {
+	// Imported dashboard content has no bindings and is used as is
+	tmpl := dashboardTemplate{
+		Template:     `{"_type":"tile/text","content":"{{tile_text}}"}`,
+		BindingsPath: path.Root("template_bindings"),
+		Strict:       true,
+	}
+
+	content, diags := tmpl.Render()
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if content != tmpl.Template {
+		t.Fatalf("expected the template to be used as is, got %s", content)
+	}
+}
+
+func TestCheckTemplateBindingsSectionContext(t *testing.T) {
+	template := `[{{#tiles}}{"x":{{x}},"last":{{^last}}false{{/last}}}{{/tiles}}]`
+	bindings := map[string]interface{}{
+		"tiles": []interface{}{
+			map[string]interface{}{"x": int64(0), "last": false},
+			map[string]interface{}{"x": int64(1), "last": true},
+		},
+	}
+
+	missing, unused, err := checkTemplateBindings(template, bindings)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(missing) != 0 || len(unused) != 0 {
+		t.Fatalf("unexpected missing %v or unused %v bindings", missing, unused)
+	}
+}
+
+func TestDashboardLayoutSetAndRemoveTile(t *testing.T) {
+	layout, err := parseDashboardLayout([]byte(`{"_type":"layout/grid","columns":4,"contents":[{"i":"1","x":0,"y":0,"w":2,"h":2,"z":0,"config":{"title":"Old"}}]}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	layout.SetTile(map[string]interface{}{"i": "1", "x": int64(0), "y": int64(0), "w": int64(2), "h": int64(3), "config": map[string]interface{}{"title": "New"}})
+	layout.SetTile(map[string]interface{}{"i": "2", "x": int64(4), "y": int64(0), "w": int64(2), "h": int64(2), "config": map[string]interface{}{"title": "Added"}})
+
+	tile, ok := layout.Tile("1")
+	if !ok {
+		t.Fatal("expected tile 1 to exist")
+	}
+	if tile["z"] != float64(0) || layoutNumber(tile["h"]) != 3 {
+		t.Errorf("expected tile 1 to be updated and keep its other keys, got %v", tile)
+	}
+	if layoutNumber(layout["columns"]) != 6 {
+		t.Errorf("expected the grid to widen to 6 columns, got %v", layout["columns"])
+	}
+
+	if !layout.RemoveTile("1") || layout.RemoveTile("1") {
+		t.Error("expected tile 1 to be removed exactly once")
+	}
+	if ids := layout.TileIDs(); !reflect.DeepEqual(ids, map[string]bool{"2": true}) {
+		t.Errorf("expected only tile 2 to remain, got %v", ids)
+	}
+}
+
+func TestExternalTiles(t *testing.T) {
+	server := json.RawMessage(`{"_type":"layout/grid","columns":6,"contents":[{"i":"1","x":0,"y":0,"w":4,"h":2,"config":{"_type":"tile/text","title":"Hello"}},{"i":"external","x":4,"y":0,"w":2,"h":2,"config":{"title":"Team tile"}}]}`)
+
+	withoutExternal, err := withoutExternalTiles(appliedDashboardContent, server)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !dashboardContentEqual(appliedDashboardContent, string(withoutExternal)) {
+		t.Errorf("expected the external tile to be ignored, got %s", withoutExternal)
+	}
+
+	merged, err := withExternalTiles(appliedDashboardContent, server)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	layout, err := parseDashboardLayout([]byte(merged))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := layout.Tile("external"); !ok {
+		t.Errorf("expected the external tile to be kept, got %s", merged)
+	}
+	if layoutNumber(layout["columns"]) != 6 {
+		t.Errorf("expected 6 columns, got %v", layout["columns"])
+	}
+}
+
+func TestImportedDashboardTemplate(t *testing.T) {
+	server := json.RawMessage(`{"_type":"layout/grid","columns":1,"contents":[{"i":"1","config":{"title":"Hello"}}]}`)
+
+	template, err := importedDashboardTemplate(server)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !dashboardContentEqual(template, string(server)) {
+		t.Errorf("expected the template to match the server content, got %s", template)
+	}
+
+	// The template is used without bindings, so it renders to the content
+	rendered, diags := dashboardTemplate{Template: template, Engine: templateEngineMustache}.Render()
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if rendered != template {
+		t.Errorf("expected the template to render unchanged, got %s", rendered)
+	}
+}
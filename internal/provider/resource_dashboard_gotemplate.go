@@ -0,0 +1,190 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/pborman/uuid"
+)
+
+// dashboardTemplateNamespace scopes the IDs generated by the uuid template
+// function, so the same names always produce the same IDs.
+var dashboardTemplateNamespace = uuid.NewSHA1(uuid.NameSpace_URL, []byte("https://github.com/squaredup/terraform-provider-squaredup/dashboard"))
+
+// goTemplateFuncs are the helper functions available to gotemplate dashboard
+// templates, in addition to the text/template builtins.
+var goTemplateFuncs = template.FuncMap{
+	"toJson":  goTemplateToJSON,
+	"uuid":    goTemplateUUID,
+	"default": goTemplateDefault,
+}
+
+func (t dashboardTemplate) renderGoTemplate() (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	missingKey := "missingkey=default"
+	if t.Strict {
+		missingKey = "missingkey=error"
+	}
+
+	tmpl, err := template.New("dashboard_template").Funcs(goTemplateFuncs).Option(missingKey).Parse(t.Template)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("dashboard_template"),
+			"Unable to parse template",
+			err.Error(),
+		)
+		return "", diags
+	}
+
+	bindings := t.Bindings
+	if bindings == nil {
+		bindings = map[string]interface{}{}
+	}
+
+	if t.Strict {
+		t.warnUnusedBindings(&diags, unusedGoTemplateBindings(tmpl, bindings))
+	}
+
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, bindings); err != nil {
+		diags.AddError(
+			"Unable to render template",
+			err.Error(),
+		)
+		return "", diags
+	}
+
+	return rendered.String(), diags
+}
+
+// goTemplateToJSON encodes a value as JSON, for example to insert a list of
+// IDs or a quoted string into the dashboard.
+func goTemplateToJSON(value interface{}) (string, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+// goTemplateUUID returns a UUID derived from its arguments, so tile IDs stay
+// stable between renders.
+func goTemplateUUID(names ...interface{}) string {
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprint(name)
+	}
+	return uuid.NewSHA1(dashboardTemplateNamespace, []byte(strings.Join(parts, "/"))).String()
+}
+
+// goTemplateDefault returns fallback when value is missing or empty.
+func goTemplateDefault(fallback interface{}, value interface{}) interface{} {
+	if value == nil {
+		return fallback
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		if v.Len() == 0 {
+			return fallback
+		}
+	default:
+		if v.IsZero() {
+			return fallback
+		}
+	}
+	return value
+}
+
+// unusedGoTemplateBindings returns the top level bindings that no field,
+// variable or index in the template refers to. Any use of the whole bindings
+// object, for example toJson ., counts as using every binding.
+func unusedGoTemplateBindings(tmpl *template.Template, bindings map[string]interface{}) []string {
+	used := map[string]bool{}
+	usesAll := false
+
+	var walk func(node parse.Node, nested bool)
+	walk = func(node parse.Node, nested bool) {
+		switch n := node.(type) {
+		case *parse.ListNode:
+			if n == nil {
+				return
+			}
+			for _, child := range n.Nodes {
+				walk(child, nested)
+			}
+		case *parse.ActionNode:
+			walk(n.Pipe, nested)
+		case *parse.TemplateNode:
+			walk(n.Pipe, nested)
+		case *parse.PipeNode:
+			if n == nil {
+				return
+			}
+			for _, cmd := range n.Cmds {
+				walk(cmd, nested)
+			}
+		case *parse.CommandNode:
+			for _, arg := range n.Args {
+				walk(arg, nested)
+			}
+		case *parse.ChainNode:
+			walk(n.Node, nested)
+		case *parse.IfNode:
+			walk(n.Pipe, nested)
+			walk(n.List, nested)
+			walk(n.ElseList, nested)
+		case *parse.RangeNode:
+			walk(n.Pipe, nested)
+			walk(n.List, true)
+			walk(n.ElseList, nested)
+		case *parse.WithNode:
+			walk(n.Pipe, nested)
+			walk(n.List, true)
+			walk(n.ElseList, nested)
+		case *parse.FieldNode:
+			used[n.Ident[0]] = true
+		case *parse.VariableNode:
+			if n.Ident[0] == "$" {
+				if len(n.Ident) > 1 {
+					used[n.Ident[1]] = true
+				} else {
+					usesAll = true
+				}
+			}
+		case *parse.StringNode:
+			used[n.Text] = true
+		case *parse.DotNode:
+			if !nested {
+				usesAll = true
+			}
+		}
+	}
+
+	for _, t := range tmpl.Templates() {
+		if t.Tree != nil {
+			walk(t.Tree.Root, false)
+		}
+	}
+
+	var unused []string
+	if usesAll {
+		return unused
+	}
+	for name := range bindings {
+		if !used[name] {
+			unused = append(unused, name)
+		}
+	}
+	sort.Strings(unused)
+	return unused
+}
@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+const goTemplateDashboard = `{
+"_type": "layout/grid",
+"contents": [
+{{- range $i, $scope := .scopes }}{{ if $i }},{{ end }}
+	{"i": "{{ uuid "tile" $scope.name }}", "x": {{ $i }}, "config": {"title": {{ toJson $scope.name }}, "scope": {"ids": {{ toJson $scope.ids }}}}}
+{{- end }}
+],
+"columns": {{ default 4 .columns }}
+}`
+
+func TestDashboardTemplateRenderGoTemplate(t *testing.T) {
+	tmpl := dashboardTemplate{
+		Template: goTemplateDashboard,
+		Engine:   templateEngineGoTemplate,
+		Bindings: map[string]interface{}{
+			"scopes": []interface{}{
+				map[string]interface{}{"name": `Web "frontend"`, "ids": []interface{}{"node-1", "node-2"}},
+				map[string]interface{}{"name": "Database", "ids": []interface{}{"node-3"}},
+			},
+		},
+		BindingsPath: path.Root("bindings"),
+	}
+
+	content, diags := tmpl.Render()
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	var dashboard struct {
+		Contents []struct {
+			I      string `json:"i"`
+			X      int    `json:"x"`
+			Config struct {
+				Title string `json:"title"`
+				Scope struct {
+					IDs []string `json:"ids"`
+				} `json:"scope"`
+			} `json:"config"`
+		} `json:"contents"`
+		Columns int `json:"columns"`
+	}
+	if err := json.Unmarshal([]byte(content), &dashboard); err != nil {
+		t.Fatalf("rendered content is not valid JSON: %v\n%s", err, content)
+	}
+
+	if dashboard.Columns != 4 || len(dashboard.Contents) != 2 {
+		t.Fatalf("unexpected dashboard: %s", content)
+	}
+	if dashboard.Contents[0].Config.Title != `Web "frontend"` || !reflect.DeepEqual(dashboard.Contents[0].Config.Scope.IDs, []string{"node-1", "node-2"}) {
+		t.Fatalf("unexpected first tile: %+v", dashboard.Contents[0])
+	}
+	if dashboard.Contents[1].X != 1 || dashboard.Contents[0].I == dashboard.Contents[1].I {
+		t.Fatalf("unexpected tile layout: %+v", dashboard.Contents)
+	}
+
+	again, _ := tmpl.Render()
+	if again != content {
+		t.Fatal("expected rendering to be deterministic")
+	}
+}
+
+func TestDashboardTemplateRenderGoTemplateStrict(t *testing.T) {
+	tmpl := dashboardTemplate{
+		Template:     `{"title": {{ toJson .title }}, "scope": {{ toJson .scpoe_id }}}`,
+		Engine:       templateEngineGoTemplate,
+		Bindings:     map[string]interface{}{"title": "Service", "scope_id": "scope-1"},
+		BindingsPath: path.Root("bindings"),
+	}
+
+	content, diags := tmpl.Render()
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if content != `{"title": "Service", "scope": null}` {
+		t.Fatalf("unexpected content: %s", content)
+	}
+
+	tmpl.Strict = true
+	_, diags = tmpl.Render()
+	if diags.ErrorsCount() != 1 || diags.WarningsCount() != 1 {
+		t.Fatalf("expected one missing and one unused binding, got %v", diags)
+	}
+}
+
+func TestDashboardTemplateRenderGoTemplateInvalidJSON(t *testing.T) {
+	tmpl := dashboardTemplate{
+		Template: `{"title": {{ .title }}}`,
+		Engine:   templateEngineGoTemplate,
+		Bindings: map[string]interface{}{"title": "not quoted"},
+	}
+
+	if _, diags := tmpl.Render(); !diags.HasError() {
+		t.Fatal("expected invalid JSON to be reported")
+	}
+}
@@ -80,16 +80,16 @@ func (r *DashboardImageResource) Configure(ctx context.Context, req resource.Con
 		return
 	}
 
-	client, ok := req.ProviderData.(*SquaredUpClient)
+	providerData, ok := req.ProviderData.(*squaredupProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Dashboard Image Resource Configure Type",
-			fmt.Sprintf("Expected *SquaredUpClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *squaredupProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
 }
 
 func (r *DashboardImageResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -107,7 +107,7 @@ func (r *DashboardImageResource) Create(ctx context.Context, req resource.Create
 		},
 	}
 
-	err := r.client.UploadDashboardImage(plan.WorkspaceId.ValueString(), plan.DashboardId.ValueString(), plan.TileId.ValueString(), &requestBody)
+	err := r.client.UploadDashboardImage(ctx, plan.WorkspaceId.ValueString(), plan.DashboardId.ValueString(), plan.TileId.ValueString(), &requestBody)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Uploading Dashboard Image",
@@ -116,7 +116,7 @@ func (r *DashboardImageResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
-	dashboardImage, err := r.client.GetDashboardImage(plan.WorkspaceId.ValueString(), plan.DashboardId.ValueString(), plan.TileId.ValueString())
+	dashboardImage, err := r.client.GetDashboardImage(ctx, plan.WorkspaceId.ValueString(), plan.DashboardId.ValueString(), plan.TileId.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Dashboard Image During Create",
@@ -149,8 +149,12 @@ func (r *DashboardImageResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
-	dashboardImage, err := r.client.GetDashboardImage(state.WorkspaceId.ValueString(), state.DashboardId.ValueString(), state.TileId.ValueString())
+	dashboardImage, err := r.client.GetDashboardImage(ctx, state.WorkspaceId.ValueString(), state.DashboardId.ValueString(), state.TileId.ValueString())
 	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error Reading Dashboard Image",
 			fmt.Sprintf("Unable to read dashboard image: %v", err),
@@ -183,7 +187,7 @@ func (r *DashboardImageResource) Update(ctx context.Context, req resource.Update
 		},
 	}
 
-	err := r.client.UploadDashboardImage(plan.WorkspaceId.ValueString(), plan.DashboardId.ValueString(), plan.TileId.ValueString(), &requestBody)
+	err := r.client.UploadDashboardImage(ctx, plan.WorkspaceId.ValueString(), plan.DashboardId.ValueString(), plan.TileId.ValueString(), &requestBody)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Dashboard Image",
@@ -192,7 +196,7 @@ func (r *DashboardImageResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
-	dashboardImage, err := r.client.GetDashboardImage(plan.WorkspaceId.ValueString(), plan.DashboardId.ValueString(), plan.TileId.ValueString())
+	dashboardImage, err := r.client.GetDashboardImage(ctx, plan.WorkspaceId.ValueString(), plan.DashboardId.ValueString(), plan.TileId.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Dashboard Image During Update",
@@ -225,7 +229,7 @@ func (r *DashboardImageResource) Delete(ctx context.Context, req resource.Delete
 		return
 	}
 
-	err := r.client.DeleteDashboardImage(state.WorkspaceId.ValueString(), state.DashboardId.ValueString(), state.TileId.ValueString())
+	err := r.client.DeleteDashboardImage(ctx, state.WorkspaceId.ValueString(), state.DashboardId.ValueString(), state.TileId.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Dashboard Image",
@@ -71,16 +71,16 @@ func (r *DashboardOrderingResource) Configure(ctx context.Context, req resource.
 		return
 	}
 
-	client, ok := req.ProviderData.(*SquaredUpClient)
+	providerData, ok := req.ProviderData.(*squaredupProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Configure Type for provider data",
-			fmt.Sprintf("Expected *SquaredUpClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *squaredupProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
 }
 
 func (r *DashboardOrderingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -99,7 +99,7 @@ func (r *DashboardOrderingResource) Create(ctx context.Context, req resource.Cre
 		return
 	}
 
-	err = r.client.UpdateWorkspace(plan.WorkspaceID.ValueString(), workspacePayload)
+	workspace, err := r.client.UpdateWorkspace(ctx, plan.WorkspaceID.ValueString(), workspacePayload)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating workspace order",
@@ -108,15 +108,6 @@ func (r *DashboardOrderingResource) Create(ctx context.Context, req resource.Cre
 		return
 	}
 
-	workspace, err := r.client.GetWorkspace(plan.WorkspaceID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error reading workspace order",
-			fmt.Sprintf("Unable to read workspace order: %v", err),
-		)
-		return
-	}
-
 	dashboardIdOrderJson, err := json.Marshal(workspace.Data.Properties.DashboardIdOrder)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -150,8 +141,12 @@ func (r *DashboardOrderingResource) Read(ctx context.Context, req resource.ReadR
 		return
 	}
 
-	workspace, err := r.client.GetWorkspace(state.WorkspaceID.ValueString())
+	workspace, err := r.client.GetWorkspace(ctx, state.WorkspaceID.ValueString())
 	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error reading workspace order",
 			fmt.Sprintf("Unable to read workspace order: %v", err),
@@ -200,7 +195,7 @@ func (r *DashboardOrderingResource) Update(ctx context.Context, req resource.Upd
 		return
 	}
 
-	err = r.client.UpdateWorkspace(plan.WorkspaceID.ValueString(), workspacePayload)
+	readWorkspace, err := r.client.UpdateWorkspace(ctx, plan.WorkspaceID.ValueString(), workspacePayload)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating workspace order",
@@ -209,15 +204,6 @@ func (r *DashboardOrderingResource) Update(ctx context.Context, req resource.Upd
 		return
 	}
 
-	readWorkspace, err := r.client.GetWorkspace(plan.WorkspaceID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error reading workspace order",
-			fmt.Sprintf("Unable to read workspace order: %v", err),
-		)
-		return
-	}
-
 	dashboardIdOrderJson, err := json.Marshal(readWorkspace.Data.Properties.DashboardIdOrder)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -257,7 +243,7 @@ func (r *DashboardOrderingResource) Delete(ctx context.Context, req resource.Del
 		},
 	}
 
-	err := r.client.UpdateWorkspace(state.WorkspaceID.ValueString(), dashboardIdOrderPayload)
+	_, err := r.client.UpdateWorkspace(ctx, state.WorkspaceID.ValueString(), dashboardIdOrderPayload)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting workspace order",
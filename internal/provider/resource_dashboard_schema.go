@@ -0,0 +1,231 @@
+package provider
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// currentDashboardSchemaVersion is the dashboard content format new
+// dashboards are created with.
+const currentDashboardSchemaVersion = "1"
+
+// dashboardSchemaFiles holds a JSON schema for each dashboard content format,
+// named after the major part of Dashboard.SchemaVersion.
+//
+//go:embed schemas/dashboard_content_v*.json
+var dashboardSchemaFiles embed.FS
+
+// jsonSchema is the subset of JSON Schema used by the embedded dashboard
+// schemas. $ref only supports local references to $defs.
+type jsonSchema struct {
+	Ref                  string                 `json:"$ref"`
+	Defs                 map[string]*jsonSchema `json:"$defs"`
+	Type                 string                 `json:"type"`
+	Const                json.RawMessage        `json:"const"`
+	Enum                 []interface{}          `json:"enum"`
+	Minimum              *float64               `json:"minimum"`
+	MinLength            *int                   `json:"minLength"`
+	Properties           map[string]*jsonSchema `json:"properties"`
+	Required             []string               `json:"required"`
+	AdditionalProperties *bool                  `json:"additionalProperties"`
+	Items                *jsonSchema            `json:"items"`
+	AllOf                []*jsonSchema          `json:"allOf"`
+	If                   *jsonSchema            `json:"if"`
+	Then                 *jsonSchema            `json:"then"`
+}
+
+// schemaViolation is a value that does not match its schema. Pointer is the
+// JSON pointer of the value, for example /contents/0/config. Properties not
+// allowed by additionalProperties are only warnings, since the UI may add
+// properties the schema does not know about yet.
+type schemaViolation struct {
+	Pointer string
+	Message string
+	Warning bool
+}
+
+// dashboardContentSchema returns the schema for a dashboard schema version.
+// An empty version is the format new dashboards are created with. It returns
+// false when the provider has no schema for the version.
+func dashboardContentSchema(schemaVersion string) (*jsonSchema, bool, error) {
+	major := strings.SplitN(schemaVersion, ".", 2)[0]
+	if major == "" {
+		major = currentDashboardSchemaVersion
+	}
+
+	content, err := dashboardSchemaFiles.ReadFile("schemas/dashboard_content_v" + major + ".json")
+	if err != nil {
+		return nil, false, nil
+	}
+
+	var schema jsonSchema
+	if err := json.Unmarshal(content, &schema); err != nil {
+		return nil, false, fmt.Errorf("dashboard schema version %s: %w", major, err)
+	}
+	return &schema, true, nil
+}
+
+// validateDashboardContent checks dashboard content against the schema for
+// schemaVersion. Content of versions without a schema is not checked.
+func validateDashboardContent(schemaVersion string, content string) ([]schemaViolation, error) {
+	schema, ok, err := dashboardContentSchema(schemaVersion)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(content), &value); err != nil {
+		return nil, err
+	}
+
+	return schema.validate(schema, value, ""), nil
+}
+
+func (s *jsonSchema) validate(root *jsonSchema, value interface{}, pointer string) []schemaViolation {
+	if s.Ref != "" {
+		ref, ok := root.resolve(s.Ref)
+		if !ok {
+			return []schemaViolation{{Pointer: pointer, Message: fmt.Sprintf("schema reference %s cannot be resolved", s.Ref)}}
+		}
+		return ref.validate(root, value, pointer)
+	}
+
+	if s.Type != "" && !jsonSchemaTypeMatches(s.Type, value) {
+		return []schemaViolation{{Pointer: pointer, Message: fmt.Sprintf("expected %s, got %s", s.Type, jsonSchemaTypeOf(value))}}
+	}
+
+	var violations []schemaViolation
+	fail := func(format string, args ...interface{}) {
+		violations = append(violations, schemaViolation{Pointer: pointer, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if s.Const != nil {
+		var expected interface{}
+		if json.Unmarshal(s.Const, &expected) == nil && !reflect.DeepEqual(expected, value) {
+			fail("expected %s", string(s.Const))
+		}
+	}
+	if s.Enum != nil && !jsonSchemaEnumContains(s.Enum, value) {
+		fail("must be one of %v", s.Enum)
+	}
+
+	switch v := value.(type) {
+	case float64:
+		if s.Minimum != nil && v < *s.Minimum {
+			fail("must be at least %v", *s.Minimum)
+		}
+	case string:
+		if s.MinLength != nil && len(v) < *s.MinLength {
+			fail("must be at least %d characters long", *s.MinLength)
+		}
+	case []interface{}:
+		if s.Items != nil {
+			for i, item := range v {
+				violations = append(violations, s.Items.validate(root, item, fmt.Sprintf("%s/%d", pointer, i))...)
+			}
+		}
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				fail("missing required property %q", name)
+			}
+		}
+
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			property, ok := s.Properties[name]
+			switch {
+			case ok:
+				violations = append(violations, property.validate(root, v[name], pointer+"/"+jsonPointerEscape(name))...)
+			case s.AdditionalProperties != nil && !*s.AdditionalProperties:
+				violations = append(violations, schemaViolation{
+					Pointer: pointer + "/" + jsonPointerEscape(name),
+					Message: fmt.Sprintf("property %q is not known", name),
+					Warning: true,
+				})
+			}
+		}
+	}
+
+	for _, schema := range s.AllOf {
+		violations = append(violations, schema.validate(root, value, pointer)...)
+	}
+	if s.If != nil && s.Then != nil && !hasSchemaErrors(s.If.validate(root, value, pointer)) {
+		violations = append(violations, s.Then.validate(root, value, pointer)...)
+	}
+
+	return violations
+}
+
+func hasSchemaErrors(violations []schemaViolation) bool {
+	for _, violation := range violations {
+		if !violation.Warning {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *jsonSchema) resolve(ref string) (*jsonSchema, bool) {
+	name, ok := strings.CutPrefix(ref, "#/$defs/")
+	if !ok {
+		return nil, false
+	}
+	schema, ok := s.Defs[name]
+	return schema, ok
+}
+
+func jsonSchemaTypeMatches(schemaType string, value interface{}) bool {
+	switch schemaType {
+	case "integer":
+		number, ok := value.(float64)
+		return ok && number == math.Trunc(number)
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	default:
+		return jsonSchemaTypeOf(value) == schemaType
+	}
+}
+
+func jsonSchemaTypeOf(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func jsonSchemaEnumContains(enum []interface{}, value interface{}) bool {
+	for _, allowed := range enum {
+		if reflect.DeepEqual(allowed, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonPointerEscape escapes a property name for use in a JSON pointer.
+func jsonPointerEscape(name string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
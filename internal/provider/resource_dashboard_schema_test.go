@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const validDashboardContent = `{
+"_type": "layout/grid",
+"columns": 4,
+"version": 1,
+"contents": [
+	{
+		"i": "1", "x": 0, "y": 0, "w": 2, "h": 2, "z": 0, "static": false, "moved": false,
+		"config": {
+			"_type": "tile/text",
+			"title": "",
+			"description": "",
+			"visualisation": {"config": {"content": "Hello", "autoSize": true}}
+		}
+	},
+	{
+		"i": "2", "x": 2, "y": 0, "w": 2, "h": 2,
+		"config": {
+			"_type": "tile/data-stream",
+			"title": "Cost",
+			"baseTile": "data-stream-base-tile",
+			"variables": ["variable-1"],
+			"dataStream": {"id": "datastream-1", "pluginConfigId": "config-1", "group": {"by": ["label"]}},
+			"scope": {"scope": "scope-1", "workspace": "space-1"},
+			"visualisation": {"type": "data-stream-scalar"},
+			"monitor": {"_type": "simple", "monitorType": "threshold", "frequency": 5, "condition": {"columns": ["cost"], "logic": {}}}
+		}
+	}
+]
+}`
+
+func TestValidateDashboardContent(t *testing.T) {
+	violations, err := validateDashboardContent("", validDashboardContent)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(violations) != 0 {
+		t.Errorf("expected no violations, got %v", violations)
+	}
+}
+
+func TestValidateDashboardContentUIExport(t *testing.T) {
+	// The sample dashboard of the provider examples, with its bindings filled in
+	content, err := os.ReadFile("testdata/dashboard_content_ui_export.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	violations, err := validateDashboardContent(currentDashboardSchemaVersion, string(content))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(violations) != 0 {
+		t.Errorf("expected no violations, got %v", violations)
+	}
+}
+
+func TestValidateDashboardContentWarnsAboutUnknownProperties(t *testing.T) {
+	content := `{
+"_type": "layout/grid",
+"contents": [
+	{"i": "1", "x": 0, "y": 0, "w": 2, "h": 2, "newLayoutOption": true, "config": {"_type": "tile/data-stream", "dataStream": {"id": "datastream-1"}, "newTileOption": {}}}
+]
+}`
+
+	violations, err := validateDashboardContent(currentDashboardSchemaVersion, content)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(violations) != 2 || hasSchemaErrors(violations) {
+		t.Errorf("expected two warnings, got %v", violations)
+	}
+}
+
+func TestValidateDashboardContentReportsPointers(t *testing.T) {
+	content := `{
+"_type": "layout/grid",
+"contents": [
+	{"i": "1", "x": 0, "y": 0, "w": 2, "h": 1.5, "config": {"_type": "tile/data-stream", "title": "Cost", "dataStraem": {"id": "datastream-1"}}}
+]
+}`
+
+	violations, err := validateDashboardContent("1.0", content)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []schemaViolation{
+		{Pointer: "/contents/0/config", Message: `missing required property "dataStream"`},
+		{Pointer: "/contents/0/config/dataStraem", Message: `property "dataStraem" is not known`, Warning: true},
+		{Pointer: "/contents/0/h", Message: "expected integer, got number"},
+	}
+	actual := map[schemaViolation]bool{}
+	for _, violation := range violations {
+		actual[violation] = true
+	}
+	for _, violation := range expected {
+		if !actual[violation] {
+			t.Errorf("expected violation %v, got %v", violation, violations)
+		}
+	}
+	if len(violations) != len(expected) {
+		t.Errorf("expected %d violations, got %v", len(expected), violations)
+	}
+}
+
+func TestValidateDashboardContentUnknownSchemaVersion(t *testing.T) {
+	violations, err := validateDashboardContent("99.1", `{"anything": true}`)
+	if err != nil || violations != nil {
+		t.Errorf("expected content of unknown schema versions not to be checked, got %v, %v", violations, err)
+	}
+}
+
+func TestValidateCompiledDashboardTiles(t *testing.T) {
+	tile := testDashboardTile("Logs", 0)
+	tile.ID = types.StringValue("tile-1")
+	tile.ScopeID = types.StringValue("scope-1")
+
+	content, diags := compileDashboardTiles(context.Background(), "space-1", []squaredupDashboardTile{tile})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	violations, err := validateDashboardContent(currentDashboardSchemaVersion, content)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(violations) != 0 {
+		t.Errorf("expected compiled tiles to match the schema, got %v", violations)
+	}
+}
+
+func TestDashboardContentSchemaReferencesResolve(t *testing.T) {
+	schema, ok, err := dashboardContentSchema(currentDashboardSchemaVersion)
+	if err != nil || !ok {
+		t.Fatalf("expected the current schema to load, got %v, %v", ok, err)
+	}
+
+	var walk func(s *jsonSchema)
+	walk = func(s *jsonSchema) {
+		if s == nil {
+			return
+		}
+		if s.Ref != "" {
+			if _, ok := schema.resolve(s.Ref); !ok {
+				t.Errorf("schema reference %s cannot be resolved", s.Ref)
+			}
+		}
+		for _, child := range s.Properties {
+			walk(child)
+		}
+		for _, child := range s.AllOf {
+			walk(child)
+		}
+		walk(s.Items)
+		walk(s.If)
+		walk(s.Then)
+	}
+
+	walk(schema)
+	for _, def := range schema.Defs {
+		walk(def)
+	}
+}
+
+func TestJSONPointerEscape(t *testing.T) {
+	if actual := jsonPointerEscape("a/b~c"); !reflect.DeepEqual(actual, "a~1b~0c") {
+		t.Errorf("unexpected escaped pointer %q", actual)
+	}
+}
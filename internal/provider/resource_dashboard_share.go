@@ -83,17 +83,17 @@ func (r *DashboardShareResource) Configure(_ context.Context, req resource.Confi
 		return
 	}
 
-	client, ok := req.ProviderData.(*SquaredUpClient)
+	providerData, ok := req.ProviderData.(*squaredupProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected type for provider data",
-			fmt.Sprintf("Expected *SquaredUpClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *squaredupProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
 }
 
 func (r *DashboardShareResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -112,7 +112,7 @@ func (r *DashboardShareResource) Create(ctx context.Context, req resource.Create
 		},
 	}
 
-	sharedDashboard, err := r.client.CreateSharedDashboard(dashboardSharePayload)
+	sharedDashboard, err := r.client.CreateSharedDashboard(ctx, dashboardSharePayload)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to share dashboard",
@@ -145,8 +145,12 @@ func (r *DashboardShareResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
-	sharedDashboard, err := r.client.GetSharedDashboard(state.DashboardShareID.ValueString())
+	sharedDashboard, err := r.client.GetSharedDashboard(ctx, state.DashboardShareID.ValueString())
 	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Unable to read shared dashboard",
 			fmt.Sprintf("Unable to read shared dashboard: %s", err.Error()),
@@ -186,7 +190,7 @@ func (r *DashboardShareResource) Update(ctx context.Context, req resource.Update
 		},
 	}
 
-	err := r.client.UpdateSharedDashboard(plan.DashboardShareID.ValueString(), dashboardSharePayload)
+	err := r.client.UpdateSharedDashboard(ctx, plan.DashboardShareID.ValueString(), dashboardSharePayload)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to update shared dashboard",
@@ -196,7 +200,7 @@ func (r *DashboardShareResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
-	sharedDashboard, err := r.client.GetSharedDashboard(plan.DashboardShareID.ValueString())
+	sharedDashboard, err := r.client.GetSharedDashboard(ctx, plan.DashboardShareID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to read shared dashboard",
@@ -230,7 +234,7 @@ func (r *DashboardShareResource) Delete(ctx context.Context, req resource.Delete
 		return
 	}
 
-	err := r.client.DeleteSharedDashboard(state.DashboardShareID.ValueString())
+	err := r.client.DeleteSharedDashboard(ctx, state.DashboardShareID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to delete shared dashboard",
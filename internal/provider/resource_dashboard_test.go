@@ -1,9 +1,16 @@
 package provider
 
 import (
+	"fmt"
+	"regexp"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 	"github.com/pborman/uuid"
 )
 
@@ -75,6 +82,16 @@ EOT
 				ImportState:             true,
 				ImportStateVerify:       true,
 				ImportStateVerifyIgnore: []string{"last_updated", "dashboard_content", "dashboard_template", "template_bindings"},
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					attributes := states[0].Attributes
+					if attributes["dashboard_template"] == "" || attributes["dashboard_template"] != attributes["dashboard_content"] {
+						return fmt.Errorf("expected dashboard_template to be the imported dashboard content, got %q", attributes["dashboard_template"])
+					}
+					if _, ok := attributes["template_bindings"]; ok {
+						return fmt.Errorf("expected template_bindings to be null, got %q", attributes["template_bindings"])
+					}
+					return nil
+				},
 			},
 			//Update Dashboard Test
 			{
@@ -125,6 +142,11 @@ EOT
 	display_name = "Sample Dashboard - Dashboard Test Updated"
 }
 `,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue("squaredup_dashboard.sample_dashboard", tfjsonpath.New("dashboard_content"), knownvalue.StringRegexp(regexp.MustCompile("Hello World"))),
+					},
+				},
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("squaredup_dashboard.sample_dashboard", "display_name", "Sample Dashboard - Dashboard Test Updated"),
 					resource.TestCheckResourceAttr("squaredup_dashboard.sample_dashboard", "timeframe", "last1hour"),
@@ -133,3 +155,336 @@ EOT
 		},
 	})
 }
+
+func TestDashboardResourceInvalidTemplateFailsAtPlan(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "squaredup_dashboard" "invalid_dashboard" {
+	dashboard_template = <<EOT
+{
+"_type": "layout/grid",
+"contents": [{{tile_text}}],
+"columns": 1,
+"version": 1
+}
+EOT
+	template_bindings = jsonencode({
+		tile_text = "Hello World"
+	})
+	workspace_id = "space-123"
+	display_name = "Invalid Dashboard - Dashboard Test"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Rendered template is not a valid JSON"),
+			},
+		},
+	})
+}
+
+func TestDashboardResourceBindings(t *testing.T) {
+	uuid := uuid.NewRandom().String()
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "squaredup_workspace" "application_workspace" {
+	display_name        = "Dashboard Bindings Test - ` + uuid + `"
+	description         = "Workspace with Dashboards for Application Team"
+	lifecycle {
+    	ignore_changes = ["workspaces_links"]
+  	}
+}
+
+resource "squaredup_dashboard" "bindings_dashboard" {
+	dashboard_template = <<EOT
+{
+"_type": "layout/grid",
+"contents": [
+	{{#tiles}}
+	{
+	"x": {{x}},
+	"h": 2,
+	"i": "{{id}}",
+	"y": 0,
+	"config": {
+		"title": "",
+		"description": "",
+		"_type": "tile/text",
+		"visualisation": {
+		"config": {
+			"content": "{{text}}",
+			"autoSize": true,
+			"fontSize": 16,
+			"align": "center"
+		}
+		}
+	},
+	"w": 1
+	}{{^last}},{{/last}}
+	{{/tiles}}
+],
+"columns": 2,
+"version": 1
+}
+EOT
+	bindings = {
+		tiles = [
+			{ id = "1", x = 0, text = "Hello", last = false },
+			{ id = "2", x = 1, text = "World", last = true },
+		]
+	}
+	strict_bindings = true
+	workspace_id    = squaredup_workspace.application_workspace.id
+	display_name    = "Bindings Dashboard - Dashboard Test"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("squaredup_dashboard.bindings_dashboard", "dashboard_content", regexp.MustCompile("World")),
+					resource.TestCheckNoResourceAttr("squaredup_dashboard.bindings_dashboard", "template_bindings"),
+				),
+			},
+		},
+	})
+}
+
+func TestDashboardResourceImportStrictBindings(t *testing.T) {
+	uuid := uuid.NewRandom().String()
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "squaredup_workspace" "application_workspace" {
+	display_name        = "Dashboard Import Test - ` + uuid + `"
+	description         = "Workspace with Dashboards for Application Team"
+	lifecycle {
+    	ignore_changes = ["workspaces_links"]
+  	}
+}
+
+resource "squaredup_dashboard" "literal_dashboard" {
+	dashboard_template = <<EOT
+{
+"_type": "layout/grid",
+"contents": [
+	{
+	"x": 0,
+	"h": 2,
+	"i": "1",
+	"y": 0,
+	"config": {
+		"title": "",
+		"description": "",
+		"_type": "tile/text",
+		"visualisation": {
+		"config": {
+			"content": "{{tile_text}}",
+			"autoSize": true,
+			"fontSize": 16,
+			"align": "center"
+		}
+		}
+	},
+	"w": 4
+	}
+],
+"columns": 1,
+"version": 1
+}
+EOT
+	template_bindings = jsonencode({
+		tile_text = "Hello {{name}}"
+	})
+	strict_bindings = true
+	workspace_id    = squaredup_workspace.application_workspace.id
+	display_name    = "Literal Dashboard - Dashboard Test"
+}
+`,
+				Check: resource.TestMatchResourceAttr("squaredup_dashboard.literal_dashboard", "dashboard_content", regexp.MustCompile(`Hello \{\{name\}\}`)),
+			},
+			// The imported content contains a literal {{name}}, which must not
+			// be reported as a missing binding
+			{
+				ResourceName:            "squaredup_dashboard.literal_dashboard",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated", "dashboard_content", "dashboard_template", "template_bindings", "strict_bindings"},
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if !regexp.MustCompile(`Hello \{\{name\}\}`).MatchString(states[0].Attributes["dashboard_template"]) {
+						return fmt.Errorf("expected the imported dashboard_template to keep the literal binding, got %q", states[0].Attributes["dashboard_template"])
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestDashboardResourceConflictingBindings(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "squaredup_dashboard" "conflicting_dashboard" {
+	dashboard_template = "{\"title\": \"{{title}}\"}"
+	template_bindings  = jsonencode({ title = "Hello" })
+	bindings           = { title = "Hello" }
+	workspace_id       = "space-123"
+	display_name       = "Conflicting Dashboard - Dashboard Test"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid Attribute Combination"),
+			},
+		},
+	})
+}
+
+func TestDashboardResourceTiles(t *testing.T) {
+	uuid := uuid.NewRandom().String()
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "squaredup_datasources" "sample_data" {
+	data_source_name = "Sample Data"
+}
+
+data "squaredup_data_streams" "sample_data_streams" {
+	data_source_id = data.squaredup_datasources.sample_data.plugins[0].id
+}
+
+resource "squaredup_datasource" "sample_data_source" {
+	display_name     = "Sample Data - Dashboard Tiles Test"
+	data_source_name = data.squaredup_datasources.sample_data.plugins[0].display_name
+}
+
+resource "squaredup_workspace" "application_workspace" {
+	display_name      = "Dashboard Tiles Test - ` + uuid + `"
+	description       = "Workspace with Dashboards for Application Team"
+	datasources_links = [squaredup_datasource.sample_data_source.id]
+}
+
+resource "squaredup_dashboard" "tiles_dashboard" {
+	workspace_id = squaredup_workspace.application_workspace.id
+	display_name = "Tiles Dashboard - Dashboard Test"
+
+	tile {
+		title          = "Logs"
+		data_source_id = squaredup_datasource.sample_data_source.id
+		data_stream_id = data.squaredup_data_streams.sample_data_streams.data_streams[0].id
+		visualisation  = "data-stream-table"
+		x              = 0
+		y              = 0
+		w              = 2
+		h              = 3
+	}
+
+	tile {
+		id             = "cost"
+		title          = "Cost"
+		data_source_id = squaredup_datasource.sample_data_source.id
+		data_stream_id = data.squaredup_data_streams.sample_data_streams.data_streams[0].id
+		x              = 2
+		y              = 0
+		w              = 2
+		h              = 3
+		monitor = {
+			column          = "data.cost"
+			error_threshold = 500
+		}
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("squaredup_dashboard.tiles_dashboard", "tile.0.id"),
+					resource.TestCheckResourceAttr("squaredup_dashboard.tiles_dashboard", "tile.1.id", "cost"),
+					resource.TestMatchResourceAttr("squaredup_dashboard.tiles_dashboard", "dashboard_content", regexp.MustCompile(`"i":"cost"`)),
+				),
+			},
+		},
+	})
+}
+
+func TestDashboardResourceTemplateAndTiles(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "squaredup_dashboard" "conflicting_dashboard" {
+	dashboard_template = "{}"
+	workspace_id       = "space-123"
+	display_name       = "Conflicting Dashboard - Dashboard Test"
+
+	tile {
+		title          = "Logs"
+		data_stream_id = "datastream-123"
+		x              = 0
+		y              = 0
+		w              = 1
+		h              = 1
+	}
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Conflicting dashboard definition"),
+			},
+		},
+	})
+}
+
+func TestDashboardResourceInvalidContentFailsAtPlan(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "squaredup_dashboard" "invalid_dashboard" {
+	dashboard_template = jsonencode({
+		_type   = "layout/grid"
+		columns = 4
+		contents = [{
+			i = "1", x = 0, y = 0, w = 2, h = 2
+			config = {
+				_type      = "tile/data-stream"
+				title      = "Cost"
+				dataStraem = { id = "datastream-123" }
+			}
+		}]
+	})
+	workspace_id = "space-123"
+	display_name = "Invalid Content Dashboard - Dashboard Test"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`/contents/0/config/dataStraem`),
+			},
+		},
+	})
+}
+
+func TestDashboardResourceStrictBindingsValue(t *testing.T) {
+	unconfigured := &DashboardResource{strictBindings: types.BoolNull()}
+	if !unconfigured.strictBindingsValue(types.BoolNull()).IsUnknown() {
+		t.Error("expected the default to be unknown before the provider is configured")
+	}
+	if !unconfigured.strictBindingsValue(types.BoolValue(true)).ValueBool() {
+		t.Error("expected the configured strict_bindings to be used")
+	}
+
+	configured := &DashboardResource{strictBindings: types.BoolValue(true)}
+	if value := configured.strictBindingsValue(types.BoolNull()); !value.ValueBool() {
+		t.Errorf("expected the provider default, got %v", value)
+	}
+	if value := configured.strictBindingsValue(types.BoolValue(false)); value.ValueBool() {
+		t.Errorf("expected strict_bindings to override the provider default, got %v", value)
+	}
+}
@@ -0,0 +1,524 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/pborman/uuid"
+)
+
+var (
+	_ resource.Resource                     = &DashboardTileResource{}
+	_ resource.ResourceWithConfigure        = &DashboardTileResource{}
+	_ resource.ResourceWithImportState      = &DashboardTileResource{}
+	_ resource.ResourceWithConfigValidators = &DashboardTileResource{}
+	_ resource.ResourceWithModifyPlan       = &DashboardTileResource{}
+)
+
+func SquaredUpDashboardTileResource() resource.Resource {
+	return &DashboardTileResource{}
+}
+
+type DashboardTileResource struct {
+	client *SquaredUpClient
+}
+
+type squaredupDashboardTileResource struct {
+	ID            types.String         `tfsdk:"id"`
+	TileID        types.String         `tfsdk:"tile_id"`
+	DashboardID   types.String         `tfsdk:"dashboard_id"`
+	TileConfig    jsontypes.Normalized `tfsdk:"tile_config"`
+	Title         types.String         `tfsdk:"title"`
+	Description   types.String         `tfsdk:"description"`
+	DataSourceID  types.String         `tfsdk:"data_source_id"`
+	DataStreamID  types.String         `tfsdk:"data_stream_id"`
+	ScopeID       types.String         `tfsdk:"scope_id"`
+	NodeIDs       types.List           `tfsdk:"node_ids"`
+	Visualisation types.String         `tfsdk:"visualisation"`
+	Timeframe     types.String         `tfsdk:"timeframe"`
+	X             types.Int64          `tfsdk:"x"`
+	Y             types.Int64          `tfsdk:"y"`
+	W             types.Int64          `tfsdk:"w"`
+	H             types.Int64          `tfsdk:"h"`
+	Monitor       types.Object         `tfsdk:"monitor"`
+	InSync        types.Bool           `tfsdk:"in_sync"`
+}
+
+func (r *DashboardTileResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dashboard_tile"
+}
+
+func (r *DashboardTileResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := dashboardTileAttributes(false)
+	attributes["id"] = schema.StringAttribute{
+		MarkdownDescription: "The ID of the tile (which is the same as the tile ID).",
+		Computed:            true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+	attributes["tile_id"] = schema.StringAttribute{
+		MarkdownDescription: "The ID of the tile, for use in `squaredup_workspace_alert` and `squaredup_dashboard_image`. A random ID is generated when not set.",
+		Optional:            true,
+		Computed:            true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+	attributes["dashboard_id"] = schema.StringAttribute{
+		MarkdownDescription: "The ID of the dashboard the tile is added to",
+		Required:            true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+	attributes["in_sync"] = schema.BoolAttribute{
+		MarkdownDescription: "Whether the tile on the dashboard matches the configuration. Refresh sets it to false when the tile was edited outside of Terraform in a way the tile attributes cannot show, so the next plan rewrites the tile.",
+		Computed:            true,
+	}
+	attributes["tile_config"] = schema.StringAttribute{
+		MarkdownDescription: "The tile configuration as a JSON encoded string, the same as the `config` of a tile in a dashboard template. Conflicts with the structured tile attributes such as `title` and `data_stream_id`.",
+		Optional:            true,
+		CustomType:          jsontypes.NormalizedType{},
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Adds a tile to an existing dashboard. The tile is merged into the dashboard content, so several teams can add tiles to a shared dashboard. " +
+			"Set `ignore_external_tiles` on a `squaredup_dashboard` that manages the same dashboard so that it keeps these tiles.",
+		Attributes: attributes,
+	}
+}
+
+func (r *DashboardTileResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	structured := []string{"title", "description", "data_source_id", "data_stream_id", "scope_id", "node_ids", "visualisation", "timeframe", "monitor"}
+
+	validators := []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(path.MatchRoot("tile_config"), path.MatchRoot("title")),
+		resourcevalidator.RequiredTogether(path.MatchRoot("title"), path.MatchRoot("data_stream_id")),
+	}
+	for _, name := range structured {
+		validators = append(validators, resourcevalidator.Conflicting(path.MatchRoot("tile_config"), path.MatchRoot(name)))
+	}
+	return validators
+}
+
+// ModifyPlan plans in_sync as true, so a tile that refresh found out of sync
+// with its configuration is rewritten.
+func (r *DashboardTileResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("in_sync"), types.BoolValue(true))...)
+}
+
+func (r *DashboardTileResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*squaredupProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Dashboard Tile Resource Configure Type",
+			fmt.Sprintf("Expected *squaredupProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+}
+
+func (r *DashboardTileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan squaredupDashboardTileResource
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.TileID.IsUnknown() || plan.TileID.IsNull() {
+		plan.TileID = types.StringValue(uuid.NewRandom().String())
+	}
+	plan.ID = plan.TileID
+	plan.InSync = types.BoolValue(true)
+
+	resp.Diagnostics.Append(r.setTile(ctx, plan, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *DashboardTileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state squaredupDashboardTileResource
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dashboard, err := r.client.GetDashboard(ctx, state.DashboardID.ValueString())
+	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unable to get dashboard",
+			err.Error(),
+		)
+		return
+	}
+
+	layout, err := parseDashboardLayout(dashboard.Content)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read dashboard content",
+			err.Error(),
+		)
+		return
+	}
+
+	tile, ok := layout.Tile(state.TileID.ValueString())
+	if !ok {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.ID = state.TileID
+	state.InSync = types.BoolValue(true)
+	state.X = types.Int64Value(int64(layoutNumber(tile["x"])))
+	state.Y = types.Int64Value(int64(layoutNumber(tile["y"])))
+	state.W = types.Int64Value(int64(layoutNumber(tile["w"])))
+	state.H = types.Int64Value(int64(layoutNumber(tile["h"])))
+
+	config, err := json.Marshal(tile["config"])
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read tile configuration",
+			err.Error(),
+		)
+		return
+	}
+
+	// Imported tiles have neither tile_config nor structured attributes, so
+	// they are adopted with their raw configuration
+	if !state.TileConfig.IsNull() || state.Title.IsNull() {
+		state.TileConfig, err = reconcileDashboardContent(state.TileConfig, config)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to read tile configuration",
+				err.Error(),
+			)
+			return
+		}
+	} else {
+		resp.Diagnostics.Append(state.readTileConfig(ctx, dashboard.WorkspaceID, config)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *DashboardTileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan squaredupDashboardTileResource
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.InSync = types.BoolValue(true)
+	resp.Diagnostics.Append(r.setTile(ctx, plan, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *DashboardTileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state squaredupDashboardTileResource
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.ModifyDashboard(ctx, state.DashboardID.ValueString(), func(dashboard *Dashboard) (string, error) {
+		layout, err := parseDashboardLayout(dashboard.Content)
+		if err != nil {
+			return "", err
+		}
+		layout.RemoveTile(state.TileID.ValueString())
+		return layout.String()
+	})
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Unable to remove tile from dashboard",
+			err.Error(),
+		)
+		return
+	}
+}
+
+func (r *DashboardTileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	idParts := strings.Split(req.ID, ",")
+
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected format: dashboard_id,tile_id, got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("dashboard_id"), types.StringValue(idParts[0]))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tile_id"), types.StringValue(idParts[1]))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), types.StringValue(idParts[1]))...)
+}
+
+// setTile writes the tile into the content of its dashboard. Unless replace
+// is true, a tile with the same ID must not exist yet, so that a tile managed
+// elsewhere is not taken over.
+func (r *DashboardTileResource) setTile(ctx context.Context, model squaredupDashboardTileResource, replace bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	_, err := r.client.ModifyDashboard(ctx, model.DashboardID.ValueString(), func(dashboard *Dashboard) (string, error) {
+		layout, err := parseDashboardLayout(dashboard.Content)
+		if err != nil {
+			return "", err
+		}
+		if _, exists := layout.Tile(model.TileID.ValueString()); exists && !replace {
+			return "", fmt.Errorf("dashboard %s already has a tile with the ID %s", dashboard.ID, model.TileID.ValueString())
+		}
+
+		tile, d := model.layoutTile(ctx, dashboard.WorkspaceID)
+		diags.Append(d...)
+		if d.HasError() {
+			return "", fmt.Errorf("unable to build the tile configuration")
+		}
+
+		layout.SetTile(tile)
+		return layout.String()
+	})
+	if err != nil && !diags.HasError() {
+		diags.AddError(
+			"Unable to add tile to dashboard",
+			err.Error(),
+		)
+	}
+
+	return diags
+}
+
+// layoutTile returns the tile as an element of the dashboard contents.
+func (m squaredupDashboardTileResource) layoutTile(ctx context.Context, workspaceID string) (map[string]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	tile := map[string]interface{}{}
+	if !m.TileConfig.IsNull() {
+		var config interface{}
+		diags.Append(m.TileConfig.Unmarshal(&config)...)
+		tile["config"] = config
+	} else {
+		compiled, d := compileDashboardTile(ctx, workspaceID, m.tile())
+		diags.Append(d...)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		encoded, err := json.Marshal(compiled)
+		if err == nil {
+			err = json.Unmarshal(encoded, &tile)
+		}
+		if err != nil {
+			diags.AddError("Unable to build the tile configuration", err.Error())
+			return nil, diags
+		}
+	}
+
+	tile["i"] = m.TileID.ValueString()
+	tile["x"] = m.X.ValueInt64()
+	tile["y"] = m.Y.ValueInt64()
+	tile["w"] = m.W.ValueInt64()
+	tile["h"] = m.H.ValueInt64()
+
+	return tile, diags
+}
+
+func (m squaredupDashboardTileResource) tile() squaredupDashboardTile {
+	return squaredupDashboardTile{
+		ID:            m.TileID,
+		Title:         m.Title,
+		Description:   m.Description,
+		DataSourceID:  m.DataSourceID,
+		DataStreamID:  m.DataStreamID,
+		ScopeID:       m.ScopeID,
+		NodeIDs:       m.NodeIDs,
+		Visualisation: m.Visualisation,
+		Timeframe:     m.Timeframe,
+		X:             m.X,
+		Y:             m.Y,
+		W:             m.W,
+		H:             m.H,
+		Monitor:       m.Monitor,
+	}
+}
+
+// readTileConfig sets the structured attributes from the tile configuration
+// on the server. Optional attributes that are not set stay null while the
+// server has the value they default to. When the tile was changed in a way
+// the attributes cannot express, in_sync is set to false, so the next plan
+// rewrites the tile.
+func (m *squaredupDashboardTileResource) readTileConfig(ctx context.Context, workspaceID string, server json.RawMessage) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var config DashboardTileConfig
+	if err := json.Unmarshal(server, &config); err != nil {
+		m.InSync = types.BoolValue(false)
+		return diags
+	}
+
+	m.Title = types.StringValue(config.Title)
+	m.Description = serverString(m.Description, config.Description)
+	m.DataStreamID = types.StringValue(config.DataStream.ID)
+	m.DataSourceID = serverString(m.DataSourceID, config.DataStream.PluginConfigID)
+	m.Timeframe = serverString(m.Timeframe, config.Timeframe)
+
+	m.Visualisation = types.StringNull()
+	if config.Visualisation != nil {
+		m.Visualisation = serverString(m.Visualisation, config.Visualisation.Type)
+	}
+
+	m.ScopeID = types.StringNull()
+	m.NodeIDs = types.ListNull(types.StringType)
+	switch {
+	case config.Scope == nil:
+	case config.Scope.Scope != "":
+		m.ScopeID = types.StringValue(config.Scope.Scope)
+	case config.Scope.QueryDetail != nil && len(config.Scope.QueryDetail.IDs) > 0:
+		nodeIDs, d := types.ListValueFrom(ctx, types.StringType, config.Scope.QueryDetail.IDs)
+		diags.Append(d...)
+		m.NodeIDs = nodeIDs
+	}
+
+	m.Monitor = readDashboardTileMonitor(m.Monitor, config.Monitor)
+	if diags.HasError() {
+		return diags
+	}
+
+	// Compile the attributes as read and check that they produce the server
+	// configuration. Keys the server adds are ignored, apart from a scope or
+	// monitor the attributes could not be read from.
+	compiled, d := compileDashboardTile(ctx, workspaceID, m.tile())
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+	encoded, err := json.Marshal(compiled.Config)
+	if err != nil {
+		diags.AddError("Unable to read tile configuration", err.Error())
+		return diags
+	}
+
+	reconciled, err := reconcileDashboardContent(jsontypes.NewNormalizedValue(string(encoded)), server)
+	if err != nil {
+		diags.AddError("Unable to read tile configuration", err.Error())
+		return diags
+	}
+	var serverKeys map[string]interface{}
+	_ = json.Unmarshal(server, &serverKeys)
+	_, hasScope := serverKeys["scope"]
+	_, hasMonitor := serverKeys["monitor"]
+	inSync := reconciled.ValueString() == string(encoded) &&
+		(!hasScope || compiled.Config.Scope != nil) &&
+		(!hasMonitor || compiled.Config.Monitor != nil)
+	m.InSync = types.BoolValue(inSync)
+
+	return diags
+}
+
+// serverString returns value as read from the server, keeping current null
+// when value is empty.
+func serverString(current types.String, value string) types.String {
+	if value == "" && current.IsNull() {
+		return current
+	}
+	return types.StringValue(value)
+}
+
+// readDashboardTileMonitor reads a threshold monitor built by
+// compileDashboardTileMonitor back into the monitor attribute. Attributes
+// that are not set stay null while the server has their default. A monitor in
+// any other form is returned as null.
+func readDashboardTileMonitor(current types.Object, monitor *DashboardTileMonitor) types.Object {
+	null := types.ObjectNull(dashboardTileMonitorAttrTypes)
+	if monitor == nil || monitor.MonitorType != "threshold" {
+		return null
+	}
+
+	var currentMonitor squaredupDashboardTileMonitor
+	if !current.IsNull() && !current.IsUnknown() {
+		if diags := current.As(context.Background(), &currentMonitor, basetypes.ObjectAsOptions{}); diags.HasError() {
+			return null
+		}
+	}
+
+	thresholds := map[string]types.Float64{
+		"error":   types.Float64Null(),
+		"warning": types.Float64Null(),
+	}
+	logic, ok := monitor.Condition.Logic["if"].([]interface{})
+	if !ok || len(logic)%2 != 0 {
+		return null
+	}
+	for i := 0; i < len(logic); i += 2 {
+		state, _ := logic[i+1].(string)
+		condition, _ := logic[i].(map[string]interface{})
+		operands, _ := condition[">"].([]interface{})
+		if _, known := thresholds[state]; !known || len(operands) != 2 {
+			return null
+		}
+		value, ok := operands[1].(float64)
+		if !ok {
+			return null
+		}
+		thresholds[state] = types.Float64Value(value)
+	}
+
+	aggregation := types.StringValue(monitor.Aggregation)
+	if monitor.Aggregation == defaultTileMonitorAggregation && currentMonitor.Aggregation.IsNull() {
+		aggregation = types.StringNull()
+	}
+	frequency := types.Int64Value(monitor.Frequency)
+	if monitor.Frequency == defaultTileMonitorFrequency && currentMonitor.Frequency.IsNull() {
+		frequency = types.Int64Null()
+	}
+	tileRollsUp := types.BoolValue(monitor.TileRollsUp)
+	if monitor.TileRollsUp && currentMonitor.TileRollsUp.IsNull() {
+		tileRollsUp = types.BoolNull()
+	}
+
+	return types.ObjectValueMust(dashboardTileMonitorAttrTypes, map[string]attr.Value{
+		"column":            types.StringValue(monitor.Column),
+		"aggregation":       aggregation,
+		"frequency":         frequency,
+		"error_threshold":   thresholds["error"],
+		"warning_threshold": thresholds["warning"],
+		"tile_rolls_up":     tileRollsUp,
+	})
+}
@@ -0,0 +1,246 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	frameworkresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/pborman/uuid"
+)
+
+func dashboardTileTestConfig(uuid string, title string, x int) string {
+	return providerConfig + `
+resource "squaredup_workspace" "application_workspace" {
+	display_name = "Dashboard Tile Test - ` + uuid + `"
+	description  = "Workspace with Dashboards for Application Team"
+}
+
+resource "squaredup_dashboard" "shared_dashboard" {
+	display_name          = "Shared Dashboard - ` + uuid + `"
+	workspace_id          = squaredup_workspace.application_workspace.id
+	ignore_external_tiles = true
+	dashboard_template    = <<EOT
+{
+  "_type": "layout/grid",
+  "contents": [
+    {
+      "x": 0, "y": 0, "w": 2, "h": 2,
+      "i": "shared-text",
+      "config": {
+        "_type": "tile/text",
+        "title": "",
+        "description": "",
+        "visualisation": { "config": { "content": "Shared" } }
+      }
+    }
+  ],
+  "version": 1,
+  "columns": 4
+}
+EOT
+}
+
+resource "squaredup_dashboard_tile" "team_tile" {
+	dashboard_id = squaredup_dashboard.shared_dashboard.id
+	x            = ` + fmt.Sprint(x) + `
+	y            = 0
+	w            = 2
+	h            = 2
+	tile_config  = jsonencode({
+		_type         = "tile/text"
+		title         = "` + title + `"
+		description   = ""
+		visualisation = { config = { content = "Team" } }
+	})
+}
+`
+}
+
+func TestAccSquaredUpDashboardTileResource(t *testing.T) {
+	uuid := uuid.NewRandom().String()
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: dashboardTileTestConfig(uuid, "Team", 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("squaredup_dashboard_tile.team_tile", "tile_id"),
+					resource.TestCheckResourceAttr("squaredup_dashboard_tile.team_tile", "x", "2"),
+					resource.TestCheckResourceAttr("squaredup_dashboard_tile.team_tile", "in_sync", "true"),
+				),
+			},
+			// The shared dashboard does not plan to remove the tile
+			{
+				Config:   dashboardTileTestConfig(uuid, "Team", 2),
+				PlanOnly: true,
+			},
+			// Import Test
+			{
+				ResourceName:      "squaredup_dashboard_tile.team_tile",
+				ImportState:       true,
+				ImportStateVerify: false,
+				ImportStateIdFunc: func(state *terraform.State) (string, error) {
+					dashboardId := state.RootModule().Resources["squaredup_dashboard.shared_dashboard"].Primary.ID
+					tileId := state.RootModule().Resources["squaredup_dashboard_tile.team_tile"].Primary.ID
+					return fmt.Sprintf("%s,%s", dashboardId, tileId), nil
+				},
+			},
+			// Update Test
+			{
+				Config: dashboardTileTestConfig(uuid, "Team Updated", 4),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("squaredup_dashboard_tile.team_tile", "x", "4"),
+					resource.TestMatchResourceAttr("squaredup_dashboard_tile.team_tile", "tile_config", regexp.MustCompile("Team Updated")),
+				),
+			},
+		},
+	})
+}
+
+func testDashboardTileResource() squaredupDashboardTileResource {
+	tile := testDashboardTile("Cost", 0)
+	return squaredupDashboardTileResource{
+		ID:            types.StringValue("tile-1"),
+		TileID:        types.StringValue("tile-1"),
+		DashboardID:   types.StringValue("dash-1"),
+		TileConfig:    jsontypes.NewNormalizedNull(),
+		Title:         tile.Title,
+		Description:   tile.Description,
+		DataSourceID:  tile.DataSourceID,
+		DataStreamID:  tile.DataStreamID,
+		ScopeID:       tile.ScopeID,
+		NodeIDs:       tile.NodeIDs,
+		Visualisation: tile.Visualisation,
+		Timeframe:     tile.Timeframe,
+		X:             tile.X,
+		Y:             tile.Y,
+		W:             tile.W,
+		H:             tile.H,
+		Monitor:       tile.Monitor,
+		InSync:        types.BoolValue(true),
+	}
+}
+
+func TestDashboardTileReadTileConfig(t *testing.T) {
+	ctx := context.Background()
+
+	model := testDashboardTileResource()
+	binding := dashboardTileBindingPrefix("tile-1") + "_0"
+	server := `{
+		"_type": "tile/data-stream",
+		"baseTile": "data-stream-base-tile",
+		"title": "Monthly cost",
+		"description": "",
+		"dataStream": {"id": "datastream-1", "pluginConfigId": "config-1", "name": "Cost"},
+		"scope": {"query": "g.V().hasId(within(` + binding + `))", "bindings": {"` + binding + `": ["node-1"]}, "queryDetail": {"ids": ["node-1"]}},
+		"monitor": {
+			"_type": "simple", "tileRollsUp": true, "monitorType": "threshold", "frequency": 5,
+			"aggregation": "top", "column": "data.cost",
+			"condition": {"columns": ["data.cost"], "logic": {"if": [{">": [{"var": "top"}, 900]}, "error"]}}
+		}
+	}`
+
+	if diags := model.readTileConfig(ctx, "space-1", json.RawMessage(server)); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if model.Title.ValueString() != "Monthly cost" {
+		t.Errorf("expected the title to be read, got %v", model.Title)
+	}
+	if !model.Description.IsNull() {
+		t.Errorf("expected the empty description to stay null, got %v", model.Description)
+	}
+	expectedNodes := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("node-1")})
+	if !model.NodeIDs.Equal(expectedNodes) {
+		t.Errorf("expected node IDs %v, got %v", expectedNodes, model.NodeIDs)
+	}
+	var monitor squaredupDashboardTileMonitor
+	if diags := model.Monitor.As(ctx, &monitor, basetypes.ObjectAsOptions{}); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if monitor.ErrorThreshold.ValueFloat64() != 900 || !monitor.Frequency.IsNull() || !monitor.WarningThreshold.IsNull() {
+		t.Errorf("unexpected monitor %+v", monitor)
+	}
+	if !model.TileConfig.IsNull() || !model.InSync.ValueBool() {
+		t.Errorf("expected the tile to be in sync, got tile_config %v and in_sync %v", model.TileConfig, model.InSync)
+	}
+}
+
+func TestDashboardTileReadTileConfigMarksUnreadableChanges(t *testing.T) {
+	model := testDashboardTileResource()
+	server := `{
+		"_type": "tile/data-stream",
+		"title": "Cost",
+		"dataStream": {"id": "datastream-1", "pluginConfigId": "config-1"},
+		"scope": {"query": "g.V().has('type', 'host')"}
+	}`
+
+	if diags := model.readTileConfig(context.Background(), "space-1", json.RawMessage(server)); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if model.InSync.ValueBool() {
+		t.Error("expected the tile to be out of sync")
+	}
+	// tile_config conflicts with the structured attributes, so it must stay
+	// null for the state to remain valid
+	if !model.TileConfig.IsNull() || model.Title.ValueString() != "Cost" {
+		t.Errorf("expected only the structured attributes to be set, got tile_config %v and title %v", model.TileConfig, model.Title)
+	}
+}
+
+func TestDashboardTileReadUIEditKeepsStateValid(t *testing.T) {
+	ctx := context.Background()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		// The tile was scoped with a custom query in the UI
+		_, _ = w.Write([]byte(`{"id":"dash-1","workspaceId":"space-1","content":{"_type":"layout/grid","columns":4,"contents":[
+			{"i":"tile-1","x":0,"y":0,"w":2,"h":3,"config":{"_type":"tile/data-stream","title":"Cost","dataStream":{"id":"datastream-1","pluginConfigId":"config-1"},"scope":{"query":"g.V().has('type', 'host')"}}}
+		]}}`))
+	})
+	r := &DashboardTileResource{client: client}
+
+	var schemaResp frameworkresource.SchemaResponse
+	r.Schema(ctx, frameworkresource.SchemaRequest{}, &schemaResp)
+
+	model := testDashboardTileResource()
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	if diags := state.Set(ctx, &model); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	readResp := frameworkresource.ReadResponse{State: state}
+	r.Read(ctx, frameworkresource.ReadRequest{State: state}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", readResp.Diagnostics)
+	}
+
+	var refreshed squaredupDashboardTileResource
+	if diags := readResp.State.Get(ctx, &refreshed); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if refreshed.InSync.ValueBool() {
+		t.Error("expected the UI edit to be detected")
+	}
+
+	// The refreshed state must still pass the resource's own validators
+	config := tfsdk.Config{Schema: readResp.State.Schema, Raw: readResp.State.Raw}
+	for _, v := range r.ConfigValidators(ctx) {
+		var validateResp frameworkresource.ValidateConfigResponse
+		v.ValidateResource(ctx, frameworkresource.ValidateConfigRequest{Config: config}, &validateResp)
+		if validateResp.Diagnostics.HasError() {
+			t.Errorf("refreshed state fails validation: %v", validateResp.Diagnostics)
+		}
+	}
+}
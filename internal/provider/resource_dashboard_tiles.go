@@ -0,0 +1,485 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+const (
+	defaultTileMonitorAggregation = "top"
+	defaultTileMonitorFrequency   = 5
+	minimumDashboardColumns       = 4
+)
+
+// dashboardTimeframes are the timeframes accepted by dashboards and tiles.
+var dashboardTimeframes = []string{
+	"last1hour",
+	"last12hours",
+	"last24hours",
+	"last7days",
+	"last30days",
+	"thisMonth",
+	"thisQuarter",
+	"thisYear",
+	"lastMonth",
+	"lastQuarter",
+	"lastYear",
+}
+
+type squaredupDashboardTile struct {
+	ID            types.String `tfsdk:"id"`
+	Title         types.String `tfsdk:"title"`
+	Description   types.String `tfsdk:"description"`
+	DataSourceID  types.String `tfsdk:"data_source_id"`
+	DataStreamID  types.String `tfsdk:"data_stream_id"`
+	ScopeID       types.String `tfsdk:"scope_id"`
+	NodeIDs       types.List   `tfsdk:"node_ids"`
+	Visualisation types.String `tfsdk:"visualisation"`
+	Timeframe     types.String `tfsdk:"timeframe"`
+	X             types.Int64  `tfsdk:"x"`
+	Y             types.Int64  `tfsdk:"y"`
+	W             types.Int64  `tfsdk:"w"`
+	H             types.Int64  `tfsdk:"h"`
+	Monitor       types.Object `tfsdk:"monitor"`
+}
+
+type squaredupDashboardTileMonitor struct {
+	Column           types.String  `tfsdk:"column"`
+	Aggregation      types.String  `tfsdk:"aggregation"`
+	Frequency        types.Int64   `tfsdk:"frequency"`
+	ErrorThreshold   types.Float64 `tfsdk:"error_threshold"`
+	WarningThreshold types.Float64 `tfsdk:"warning_threshold"`
+	TileRollsUp      types.Bool    `tfsdk:"tile_rolls_up"`
+}
+
+var dashboardTileMonitorAttrTypes = map[string]attr.Type{
+	"column":            types.StringType,
+	"aggregation":       types.StringType,
+	"frequency":         types.Int64Type,
+	"error_threshold":   types.Float64Type,
+	"warning_threshold": types.Float64Type,
+	"tile_rolls_up":     types.BoolType,
+}
+
+var dashboardTileAttrTypes = map[string]attr.Type{
+	"id":             types.StringType,
+	"title":          types.StringType,
+	"description":    types.StringType,
+	"data_source_id": types.StringType,
+	"data_stream_id": types.StringType,
+	"scope_id":       types.StringType,
+	"node_ids":       types.ListType{ElemType: types.StringType},
+	"visualisation":  types.StringType,
+	"timeframe":      types.StringType,
+	"x":              types.Int64Type,
+	"y":              types.Int64Type,
+	"w":              types.Int64Type,
+	"h":              types.Int64Type,
+	"monitor":        types.ObjectType{AttrTypes: dashboardTileMonitorAttrTypes},
+}
+
+// dashboardTileAttributes returns the schema of a tile's content and layout,
+// shared by the tile block of squaredup_dashboard and squaredup_dashboard_tile.
+// required is false when the title and data stream may instead come from raw
+// tile JSON.
+func dashboardTileAttributes(required bool) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"title": schema.StringAttribute{
+			MarkdownDescription: "The title of the tile",
+			Required:            required,
+			Optional:            !required,
+		},
+		"description": schema.StringAttribute{
+			MarkdownDescription: "The description of the tile",
+			Optional:            true,
+		},
+		"data_source_id": schema.StringAttribute{
+			MarkdownDescription: "The ID of the data source the data stream belongs to",
+			Optional:            true,
+		},
+		"data_stream_id": schema.StringAttribute{
+			MarkdownDescription: "The ID of the data stream shown by the tile",
+			Required:            required,
+			Optional:            !required,
+		},
+		"scope_id": schema.StringAttribute{
+			MarkdownDescription: "The ID of a `squaredup_scope` the tile is scoped to. Conflicts with `node_ids`.",
+			Optional:            true,
+			Validators:          []validator.String{stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("node_ids"))},
+		},
+		"node_ids": schema.ListAttribute{
+			MarkdownDescription: "IDs of the nodes the tile is scoped to. Conflicts with `scope_id`.",
+			Optional:            true,
+			ElementType:         types.StringType,
+		},
+		"visualisation": schema.StringAttribute{
+			MarkdownDescription: "The visualisation type, for example `data-stream-table` or `data-stream-scalar`",
+			Optional:            true,
+		},
+		"timeframe": schema.StringAttribute{
+			MarkdownDescription: "The timeframe of the tile. Uses the dashboard timeframe when not set.",
+			Optional:            true,
+			Validators:          []validator.String{stringvalidator.OneOf(dashboardTimeframes...)},
+		},
+		"x": schema.Int64Attribute{
+			MarkdownDescription: "The column of the top left corner of the tile",
+			Required:            true,
+			Validators:          []validator.Int64{int64validator.AtLeast(0)},
+		},
+		"y": schema.Int64Attribute{
+			MarkdownDescription: "The row of the top left corner of the tile",
+			Required:            true,
+			Validators:          []validator.Int64{int64validator.AtLeast(0)},
+		},
+		"w": schema.Int64Attribute{
+			MarkdownDescription: "The width of the tile in columns",
+			Required:            true,
+			Validators:          []validator.Int64{int64validator.AtLeast(1)},
+		},
+		"h": schema.Int64Attribute{
+			MarkdownDescription: "The height of the tile in rows",
+			Required:            true,
+			Validators:          []validator.Int64{int64validator.AtLeast(1)},
+		},
+		"monitor": schema.SingleNestedAttribute{
+			MarkdownDescription: "Threshold monitor for the tile",
+			Optional:            true,
+			Attributes: map[string]schema.Attribute{
+				"column": schema.StringAttribute{
+					MarkdownDescription: "The column to monitor",
+					Required:            true,
+				},
+				"aggregation": schema.StringAttribute{
+					MarkdownDescription: "How the column values are aggregated before comparing them with the thresholds. Defaults to `top`.",
+					Optional:            true,
+				},
+				"frequency": schema.Int64Attribute{
+					MarkdownDescription: "How often the monitor is evaluated, in minutes. Defaults to 5.",
+					Optional:            true,
+					Validators:          []validator.Int64{int64validator.AtLeast(1)},
+				},
+				"error_threshold": schema.Float64Attribute{
+					MarkdownDescription: "The tile is in error when the aggregated value is above this threshold",
+					Optional:            true,
+					Validators:          []validator.Float64{float64validator.AtLeastOneOf(path.MatchRelative().AtParent().AtName("warning_threshold"))},
+				},
+				"warning_threshold": schema.Float64Attribute{
+					MarkdownDescription: "The tile is in warning when the aggregated value is above this threshold",
+					Optional:            true,
+				},
+				"tile_rolls_up": schema.BoolAttribute{
+					MarkdownDescription: "Whether the tile state rolls up to the dashboard state. Defaults to true.",
+					Optional:            true,
+				},
+			},
+		},
+	}
+}
+
+// hasDashboardTiles reports whether any tile blocks are configured. Terraform
+// sends an empty list, rather than null, when there are none.
+func hasDashboardTiles(tiles types.List) bool {
+	return tiles.IsUnknown() || len(tiles.Elements()) > 0
+}
+
+// planDashboardTileIDs sets the ID of every tile that has none configured in
+// the plan. IDs that are unknown in config are left unknown. Tiles that were
+// already applied keep the ID they had in prior, so renaming or moving a tile
+// doesn't break references to it.
+func planDashboardTileIDs(ctx context.Context, config squaredupDashboard, plan *squaredupDashboard, prior types.List) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if config.Tiles.IsUnknown() || plan.Tiles.IsUnknown() {
+		return diags
+	}
+
+	var configured, tiles, priorTiles []squaredupDashboardTile
+	diags.Append(config.Tiles.ElementsAs(ctx, &configured, false)...)
+	diags.Append(plan.Tiles.ElementsAs(ctx, &tiles, false)...)
+	if !prior.IsNull() && !prior.IsUnknown() {
+		diags.Append(prior.ElementsAs(ctx, &priorTiles, false)...)
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	for i := range tiles {
+		if i < len(configured) {
+			tiles[i].ID = configured[i].ID
+		}
+	}
+	assignDashboardTileIDs(tiles, priorTiles)
+
+	for _, problem := range validateDashboardTiles(tiles) {
+		diags.AddAttributeError(path.Root("tile"), "Invalid tile", problem)
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	tilesValue, d := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: dashboardTileAttrTypes}, tiles)
+	diags.Append(d...)
+	if !diags.HasError() {
+		plan.Tiles = tilesValue
+	}
+	return diags
+}
+
+// assignDashboardTileIDs sets the ID of every tile whose ID is null, leaving
+// unknown IDs as they are. A tile keeps the ID of the prior tile with the same
+// title or, when it was renamed, showing the same data stream. Other tiles get an ID derived from their title, which stays the same
+// between plans. Tiles sharing a title are told apart by how many tiles before
+// them have the same title. Generated IDs never collide with other tiles' IDs.
+func assignDashboardTileIDs(tiles []squaredupDashboardTile, prior []squaredupDashboardTile) {
+	taken := map[string]bool{}
+	var pending []int
+	for i, tile := range tiles {
+		switch {
+		case tile.ID.IsNull():
+			pending = append(pending, i)
+		case !tile.ID.IsUnknown():
+			taken[tile.ID.ValueString()] = true
+		}
+	}
+
+	used := make([]bool, len(prior))
+	carry := func(i, j int) bool {
+		id := prior[j].ID
+		if used[j] || id.IsNull() || id.IsUnknown() || id.ValueString() == "" || taken[id.ValueString()] {
+			return false
+		}
+		used[j] = true
+		taken[id.ValueString()] = true
+		tiles[i].ID = id
+		return true
+	}
+
+	// Match the closest prior tile first, so a renamed tile is not given the
+	// ID of another tile that kept its title
+	sameTitle := func(i, j int) bool { return !tiles[i].Title.IsUnknown() && prior[j].Title.Equal(tiles[i].Title) }
+	sameStream := func(i, j int) bool {
+		return !tiles[i].DataStreamID.IsUnknown() && prior[j].DataStreamID.Equal(tiles[i].DataStreamID)
+	}
+	matches := []func(i, j int) bool{
+		func(i, j int) bool { return sameTitle(i, j) && sameStream(i, j) },
+		sameTitle,
+		func(i, j int) bool { return i == j && sameStream(i, j) },
+		sameStream,
+	}
+	for _, match := range matches {
+		var unmatched []int
+		for _, i := range pending {
+			matched := false
+			for j := range prior {
+				if match(i, j) && carry(i, j) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				unmatched = append(unmatched, i)
+			}
+		}
+		pending = unmatched
+	}
+	fresh := pending
+
+	seen := map[string]int{}
+	for _, i := range fresh {
+		if tiles[i].Title.IsUnknown() {
+			tiles[i].ID = types.StringUnknown()
+			continue
+		}
+
+		title := tiles[i].Title.ValueString()
+		id := goTemplateUUID("tile", title, seen[title])
+		seen[title]++
+		for taken[id] {
+			id = goTemplateUUID("tile", title, seen[title])
+			seen[title]++
+		}
+		taken[id] = true
+		tiles[i].ID = types.StringValue(id)
+	}
+}
+
+// compileDashboardTiles builds the dashboard content for a grid of tiles.
+func compileDashboardTiles(ctx context.Context, workspaceID string, tiles []squaredupDashboardTile) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	content := DashboardContent{
+		Type:     "layout/grid",
+		Contents: make([]DashboardTile, 0, len(tiles)),
+		Columns:  minimumDashboardColumns,
+		Version:  1,
+	}
+
+	for _, tile := range tiles {
+		compiled, d := compileDashboardTile(ctx, workspaceID, tile)
+		diags.Append(d...)
+		if diags.HasError() {
+			return "", diags
+		}
+
+		if right := compiled.X + compiled.W; right > content.Columns {
+			content.Columns = right
+		}
+		content.Contents = append(content.Contents, compiled)
+	}
+
+	encoded, err := json.Marshal(content)
+	if err != nil {
+		diags.AddError("Unable to compile dashboard tiles", err.Error())
+		return "", diags
+	}
+
+	return string(encoded), diags
+}
+
+func compileDashboardTile(ctx context.Context, workspaceID string, tile squaredupDashboardTile) (DashboardTile, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	config := DashboardTileConfig{
+		Type:        "tile/data-stream",
+		BaseTile:    "data-stream-base-tile",
+		Title:       tile.Title.ValueString(),
+		Description: tile.Description.ValueString(),
+		DataStream: DashboardTileDataStream{
+			ID:             tile.DataStreamID.ValueString(),
+			PluginConfigID: tile.DataSourceID.ValueString(),
+		},
+		Timeframe: tile.Timeframe.ValueString(),
+	}
+
+	if !tile.Visualisation.IsNull() {
+		config.Visualisation = &DashboardTileVisualisation{Type: tile.Visualisation.ValueString()}
+	}
+
+	switch {
+	case !tile.ScopeID.IsNull():
+		config.Scope = &DashboardTileScope{
+			Scope:     tile.ScopeID.ValueString(),
+			Workspace: workspaceID,
+		}
+	case !tile.NodeIDs.IsNull():
+		var nodeIDs []string
+		diags.Append(tile.NodeIDs.ElementsAs(ctx, &nodeIDs, false)...)
+		if diags.HasError() {
+			return DashboardTile{}, diags
+		}
+
+		query := newPrefixedGremlinQuery(dashboardTileBindingPrefix(tile.ID.ValueString()))
+		query.Step(".hasId(within(%s))", nodeIDs)
+		config.Scope = &DashboardTileScope{
+			Query:       query.String(),
+			Bindings:    query.Bindings(),
+			QueryDetail: &ScopeQueryDetail{IDs: nodeIDs},
+		}
+	}
+
+	if !tile.Monitor.IsNull() {
+		var monitor squaredupDashboardTileMonitor
+		diags.Append(tile.Monitor.As(ctx, &monitor, basetypes.ObjectAsOptions{})...)
+		if diags.HasError() {
+			return DashboardTile{}, diags
+		}
+		config.Monitor = compileDashboardTileMonitor(monitor)
+	}
+
+	return DashboardTile{
+		ID:     tile.ID.ValueString(),
+		X:      tile.X.ValueInt64(),
+		Y:      tile.Y.ValueInt64(),
+		W:      tile.W.ValueInt64(),
+		H:      tile.H.ValueInt64(),
+		Config: config,
+	}, diags
+}
+
+// dashboardTileBindingPrefix names the scope bindings of a tile. It is derived
+// from the tile ID rather than random, so the compiled content stays the same
+// between plans.
+func dashboardTileBindingPrefix(tileID string) string {
+	return "ids_" + strings.ReplaceAll(goTemplateUUID("tile-scope", tileID), "-", "")[:20]
+}
+
+// compileDashboardTileMonitor builds a threshold monitor that reports error
+// or warning when the aggregated column is above the thresholds.
+func compileDashboardTileMonitor(monitor squaredupDashboardTileMonitor) *DashboardTileMonitor {
+	aggregation := defaultTileMonitorAggregation
+	if !monitor.Aggregation.IsNull() {
+		aggregation = monitor.Aggregation.ValueString()
+	}
+	frequency := int64(defaultTileMonitorFrequency)
+	if !monitor.Frequency.IsNull() {
+		frequency = monitor.Frequency.ValueInt64()
+	}
+	tileRollsUp := true
+	if !monitor.TileRollsUp.IsNull() {
+		tileRollsUp = monitor.TileRollsUp.ValueBool()
+	}
+
+	var logic []interface{}
+	for _, threshold := range []struct {
+		value types.Float64
+		state string
+	}{
+		{monitor.ErrorThreshold, "error"},
+		{monitor.WarningThreshold, "warning"},
+	} {
+		if threshold.value.IsNull() {
+			continue
+		}
+		logic = append(logic, map[string]interface{}{
+			">": []interface{}{
+				map[string]interface{}{"var": aggregation},
+				threshold.value.ValueFloat64(),
+			},
+		}, threshold.state)
+	}
+
+	column := monitor.Column.ValueString()
+	return &DashboardTileMonitor{
+		Type:        "simple",
+		TileRollsUp: tileRollsUp,
+		MonitorType: "threshold",
+		Frequency:   frequency,
+		Aggregation: aggregation,
+		Column:      column,
+		Condition: DashboardTileMonitorCondition{
+			Columns: []string{column},
+			Logic:   map[string]interface{}{"if": logic},
+		},
+	}
+}
+
+// validateDashboardTiles reports tiles that cannot be compiled, such as two
+// tiles with the same ID.
+func validateDashboardTiles(tiles []squaredupDashboardTile) []string {
+	var problems []string
+
+	ids := map[string]bool{}
+	for _, tile := range tiles {
+		if tile.ID.IsNull() || tile.ID.IsUnknown() {
+			continue
+		}
+		if ids[tile.ID.ValueString()] {
+			problems = append(problems, fmt.Sprintf("More than one tile has the ID %q.", tile.ID.ValueString()))
+		}
+		ids[tile.ID.ValueString()] = true
+	}
+
+	return problems
+}
@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func testDashboardTile(title string, x int64) squaredupDashboardTile {
+	return squaredupDashboardTile{
+		ID:            types.StringNull(),
+		Title:         types.StringValue(title),
+		Description:   types.StringNull(),
+		DataSourceID:  types.StringValue("config-1"),
+		DataStreamID:  types.StringValue("datastream-1"),
+		ScopeID:       types.StringNull(),
+		NodeIDs:       types.ListNull(types.StringType),
+		Visualisation: types.StringNull(),
+		Timeframe:     types.StringNull(),
+		X:             types.Int64Value(x),
+		Y:             types.Int64Value(0),
+		W:             types.Int64Value(2),
+		H:             types.Int64Value(3),
+		Monitor:       types.ObjectNull(dashboardTileMonitorAttrTypes),
+	}
+}
+
+func TestAssignDashboardTileIDs(t *testing.T) {
+	tiles := []squaredupDashboardTile{
+		testDashboardTile("Cost", 0),
+		testDashboardTile("Cost", 2),
+		testDashboardTile("Logs", 4),
+	}
+	tiles[2].ID = types.StringValue("logs")
+
+	assignDashboardTileIDs(tiles, nil)
+
+	if tiles[0].ID.ValueString() == tiles[1].ID.ValueString() {
+		t.Errorf("tiles with the same title got the same ID %q", tiles[0].ID.ValueString())
+	}
+	if tiles[2].ID.ValueString() != "logs" {
+		t.Errorf("configured ID was replaced with %q", tiles[2].ID.ValueString())
+	}
+
+	// Moving a tile keeps its ID
+	moved := []squaredupDashboardTile{testDashboardTile("Cost", 6)}
+	assignDashboardTileIDs(moved, nil)
+	if moved[0].ID.ValueString() != tiles[0].ID.ValueString() {
+		t.Errorf("expected ID %q after moving the tile, got %q", tiles[0].ID.ValueString(), moved[0].ID.ValueString())
+	}
+}
+
+func TestAssignDashboardTileIDsKeepsPriorIDs(t *testing.T) {
+	prior := []squaredupDashboardTile{testDashboardTile("Cost", 0), testDashboardTile("Logs", 2)}
+	prior[0].ID = types.StringValue("cost-id")
+	prior[1].ID = types.StringValue("logs-id")
+
+	// A new tile with the same title is added first and the logs tile is renamed
+	tiles := []squaredupDashboardTile{
+		testDashboardTile("Cost", 0),
+		testDashboardTile("Cost", 2),
+		testDashboardTile("Log lines", 4),
+	}
+	tiles[0].DataStreamID = types.StringValue("datastream-2")
+	tiles[1].DataStreamID = types.StringValue("datastream-1")
+	assignDashboardTileIDs(tiles, prior)
+
+	if tiles[1].ID.ValueString() != "cost-id" {
+		t.Errorf("expected the existing Cost tile to keep its ID, got %q", tiles[1].ID.ValueString())
+	}
+	if id := tiles[0].ID.ValueString(); id == "" || id == "cost-id" || id == "logs-id" {
+		t.Errorf("expected a new ID for the new tile, got %q", id)
+	}
+	if tiles[2].ID.ValueString() != "logs-id" {
+		t.Errorf("expected the renamed tile to keep its ID, got %q", tiles[2].ID.ValueString())
+	}
+}
+
+func TestAssignDashboardTileIDsLeavesUnknownIDs(t *testing.T) {
+	tiles := []squaredupDashboardTile{testDashboardTile("Cost", 0)}
+	tiles[0].ID = types.StringUnknown()
+
+	assignDashboardTileIDs(tiles, nil)
+
+	if !tiles[0].ID.IsUnknown() {
+		t.Errorf("expected the unknown ID to stay unknown, got %q", tiles[0].ID.ValueString())
+	}
+}
+
+func TestAssignDashboardTileIDsAvoidsConfiguredIDs(t *testing.T) {
+	generated := []squaredupDashboardTile{testDashboardTile("Cost", 0)}
+	assignDashboardTileIDs(generated, nil)
+
+	tiles := []squaredupDashboardTile{testDashboardTile("Logs", 0), testDashboardTile("Cost", 2)}
+	tiles[0].ID = generated[0].ID
+	assignDashboardTileIDs(tiles, nil)
+
+	if tiles[1].ID.ValueString() == tiles[0].ID.ValueString() {
+		t.Errorf("generated ID collides with the configured ID %q", tiles[0].ID.ValueString())
+	}
+	if problems := validateDashboardTiles(tiles); len(problems) != 0 {
+		t.Errorf("expected no problems, got %v", problems)
+	}
+}
+
+func TestCompileDashboardTiles(t *testing.T) {
+	ctx := context.Background()
+
+	scoped := testDashboardTile("Logs", 0)
+	scoped.ID = types.StringValue("tile-1")
+	scoped.ScopeID = types.StringValue("scope-1")
+	scoped.Visualisation = types.StringValue("data-stream-table")
+
+	monitored := testDashboardTile("Cost", 3)
+	monitored.ID = types.StringValue("tile-2")
+	monitored.NodeIDs = types.ListValueMust(types.StringType, []attr.Value{types.StringValue("node-1")})
+	monitored.Monitor = types.ObjectValueMust(dashboardTileMonitorAttrTypes, map[string]attr.Value{
+		"column":            types.StringValue("data.cost"),
+		"aggregation":       types.StringNull(),
+		"frequency":         types.Int64Null(),
+		"error_threshold":   types.Float64Value(500),
+		"warning_threshold": types.Float64Null(),
+		"tile_rolls_up":     types.BoolNull(),
+	})
+
+	content, diags := compileDashboardTiles(ctx, "space-1", []squaredupDashboardTile{scoped, monitored})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	var dashboard map[string]interface{}
+	if err := json.Unmarshal([]byte(content), &dashboard); err != nil {
+		t.Fatalf("compiled content is not valid JSON: %v", err)
+	}
+
+	if dashboard["columns"] != float64(5) {
+		t.Errorf("expected 5 columns, got %v", dashboard["columns"])
+	}
+
+	contents := dashboard["contents"].([]interface{})
+	if len(contents) != 2 {
+		t.Fatalf("expected 2 tiles, got %d", len(contents))
+	}
+
+	first := contents[0].(map[string]interface{})["config"].(map[string]interface{})
+	expectedScope := map[string]interface{}{"scope": "scope-1", "workspace": "space-1"}
+	if !reflect.DeepEqual(first["scope"], expectedScope) {
+		t.Errorf("expected scope %v, got %v", expectedScope, first["scope"])
+	}
+
+	second := contents[1].(map[string]interface{})
+	if second["i"] != "tile-2" {
+		t.Errorf("expected tile ID tile-2, got %v", second["i"])
+	}
+	monitor := second["config"].(map[string]interface{})["monitor"].(map[string]interface{})
+	expectedLogic := map[string]interface{}{
+		"if": []interface{}{
+			map[string]interface{}{">": []interface{}{map[string]interface{}{"var": "top"}, float64(500)}},
+			"error",
+		},
+	}
+	if !reflect.DeepEqual(monitor["condition"].(map[string]interface{})["logic"], expectedLogic) {
+		t.Errorf("unexpected monitor logic: %v", monitor["condition"])
+	}
+	if monitor["frequency"] != float64(defaultTileMonitorFrequency) || monitor["tileRollsUp"] != true {
+		t.Errorf("monitor defaults were not applied: %v", monitor)
+	}
+}
+
+func TestValidateDashboardTilesDuplicateIDs(t *testing.T) {
+	first := testDashboardTile("Logs", 0)
+	first.ID = types.StringValue("tile-1")
+	second := testDashboardTile("Cost", 2)
+	second.ID = types.StringValue("tile-1")
+
+	if problems := validateDashboardTiles([]squaredupDashboardTile{first, second}); len(problems) != 1 {
+		t.Errorf("expected one problem, got %v", problems)
+	}
+}
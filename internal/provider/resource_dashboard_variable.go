@@ -88,7 +88,7 @@ func (r *DashboardVariableResource) Configure(ctx context.Context, req resource.
 		return
 	}
 
-	client, ok := req.ProviderData.(*SquaredUpClient)
+	providerData, ok := req.ProviderData.(*squaredupProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Configure Type",
@@ -97,7 +97,7 @@ func (r *DashboardVariableResource) Configure(ctx context.Context, req resource.
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
 }
 
 func (r *DashboardVariableResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -124,7 +124,7 @@ func (r *DashboardVariableResource) Create(ctx context.Context, req resource.Cre
 		AllowMultipleSelection: plan.AllowMultipleObjectSelection.ValueBool(),
 	}
 
-	variableRead, err := r.client.CreateDashboardVariable(variable, plan.WorkspaceID.ValueString())
+	variableRead, err := r.client.CreateDashboardVariable(ctx, variable, plan.WorkspaceID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create dashboard variable",
@@ -157,8 +157,12 @@ func (r *DashboardVariableResource) Read(ctx context.Context, req resource.ReadR
 		return
 	}
 
-	variable, err := r.client.GetDashboardVariable(state.ID.ValueString())
+	variable, err := r.client.GetDashboardVariable(ctx, state.ID.ValueString())
 	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Unable to read dashboard variable",
 			err.Error(),
@@ -198,7 +202,7 @@ func (r *DashboardVariableResource) Update(ctx context.Context, req resource.Upd
 	}
 
 	// get dashboard id by performing a read
-	variableRead, err := r.client.GetDashboardVariable(plan.ID.ValueString())
+	variableRead, err := r.client.GetDashboardVariable(ctx, plan.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to read dashboard variable",
@@ -219,7 +223,7 @@ func (r *DashboardVariableResource) Update(ctx context.Context, req resource.Upd
 		variable.DashboardID = variableRead.Content.DashboardID
 	}
 
-	variableUpdate, err := r.client.UpdateDashboardVariable(plan.ID.ValueString(), variable)
+	variableUpdate, err := r.client.UpdateDashboardVariable(ctx, plan.ID.ValueString(), variable)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to update dashboard variable",
@@ -252,7 +256,7 @@ func (r *DashboardVariableResource) Delete(ctx context.Context, req resource.Del
 		return
 	}
 
-	err := r.client.DeleteDashboardVariable(state.ID.ValueString())
+	err := r.client.DeleteDashboardVariable(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to delete dashboard variable",
@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// fakeDashboardVariables serves the variables API, recording the dashboard
+// each variable is bound to and which variables were updated. Like the API,
+// an update without a dashboardId keeps the current binding.
+type fakeDashboardVariables struct {
+	mu         sync.Mutex
+	dashboards map[string]string
+	updated    []string
+	gets       int
+}
+
+func (f *fakeDashboardVariables) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Method == http.MethodGet {
+		f.gets++
+	}
+
+	if r.URL.Path == "/api/workspaces/space-1/variables" {
+		variables := []map[string]interface{}{}
+		for id, dashboardID := range f.dashboards {
+			variables = append(variables, map[string]interface{}{
+				"id":      id,
+				"name":    "Objects",
+				"content": map[string]interface{}{"name": "Objects", "type": "object", "dashboardId": dashboardID},
+			})
+		}
+		_ = json.NewEncoder(w).Encode(variables)
+		return
+	}
+
+	id := strings.TrimPrefix(r.URL.Path, "/api/variables/")
+	dashboardID, ok := f.dashboards[id]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	if r.Method == http.MethodPut {
+		var variable map[string]interface{}
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &variable)
+		if value, ok := variable["dashboardId"]; ok {
+			dashboardID, _ = value.(string)
+		}
+		f.dashboards[id] = dashboardID
+		f.updated = append(f.updated, id)
+	}
+
+	_, _ = fmt.Fprintf(w, `{"id":%q,"name":"Objects","content":{"name":"Objects","type":"object","dashboardId":%q}}`, id, dashboardID)
+}
+
+func stringSet(values ...string) types.Set {
+	elements := make([]attr.Value, len(values))
+	for i, value := range values {
+		elements[i] = types.StringValue(value)
+	}
+	return types.SetValueMust(types.StringType, elements)
+}
+
+func TestAttachDashboardVariables(t *testing.T) {
+	ctx := context.Background()
+	variables := &fakeDashboardVariables{dashboards: map[string]string{
+		"environment": "dash-1",
+		"region":      "",
+		"team":        "dash-1",
+	}}
+	r := &DashboardResource{client: newTestClient(t, variables.ServeHTTP)}
+
+	diags := r.attachDashboardVariables(ctx, "dash-1", stringSet("region", "team"), stringSet("environment", "team"))
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	expected := map[string]string{"environment": "", "region": "dash-1", "team": "dash-1"}
+	for id, dashboardID := range expected {
+		if variables.dashboards[id] != dashboardID {
+			t.Errorf("expected variable %s to be bound to %q, got %q", id, dashboardID, variables.dashboards[id])
+		}
+	}
+	if len(variables.updated) != 2 {
+		t.Errorf("expected only the added and removed variables to be updated, got %v", variables.updated)
+	}
+}
+
+func TestBoundDashboardVariables(t *testing.T) {
+	variables := &fakeDashboardVariables{dashboards: map[string]string{
+		"environment": "dash-1",
+		"region":      "dash-2",
+		// Attached to the dashboard outside of Terraform
+		"team": "dash-1",
+	}}
+	r := &DashboardResource{client: newTestClient(t, variables.ServeHTTP)}
+
+	bound, err := r.boundDashboardVariables(context.Background(), "space-1", "dash-1", stringSet("environment", "region", "deleted"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bound.Equal(stringSet("environment", "team")) {
+		t.Errorf("expected environment and team to be bound, got %v", bound)
+	}
+	if variables.gets != 1 {
+		t.Errorf("expected a single request, got %d", variables.gets)
+	}
+}
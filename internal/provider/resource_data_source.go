@@ -91,17 +91,17 @@ func (r *dataSourceResource) Configure(_ context.Context, req resource.Configure
 		return
 	}
 
-	client, ok := req.ProviderData.(*SquaredUpClient)
+	providerData, ok := req.ProviderData.(*squaredupProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *SquaredUpClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *squaredupProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
 }
 
 func (r *dataSourceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -123,7 +123,7 @@ func (r *dataSourceResource) Create(ctx context.Context, req resource.CreateRequ
 		}
 	}
 
-	newDataSource, err := r.client.AddDataSource(
+	newDataSource, err := r.client.AddDataSource(ctx,
 		plan.DisplayName.ValueString(),
 		plan.Name.ValueString(),
 		plan.OnPrem.ValueBoolPointer(),
@@ -166,8 +166,12 @@ func (r *dataSourceResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	readDataSource, err := r.client.GetDataSource(state.ID.ValueString())
+	readDataSource, err := r.client.GetDataSource(ctx, state.ID.ValueString())
 	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error getting data source",
 			fmt.Sprintf("Error getting data source: %v", err),
@@ -218,7 +222,7 @@ func (r *dataSourceResource) Update(ctx context.Context, req resource.UpdateRequ
 		}
 	}
 
-	err := r.client.UpdateDataSource(
+	err := r.client.UpdateDataSource(ctx,
 		state.ID.ValueString(),
 		plan.DisplayName.ValueString(),
 		plan.Name.ValueString(),
@@ -234,7 +238,7 @@ func (r *dataSourceResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
-	getDataSource, err := r.client.GetDataSource(state.ID.ValueString())
+	getDataSource, err := r.client.GetDataSource(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error getting data source",
@@ -271,7 +275,7 @@ func (r *dataSourceResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
-	err := r.client.DeleteDataSource(state.ID.ValueString())
+	err := r.client.DeleteDataSource(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to delete data source",
@@ -117,17 +117,17 @@ func (r *ScopeResource) Configure(_ context.Context, req resource.ConfigureReque
 		return
 	}
 
-	client, ok := req.ProviderData.(*SquaredUpClient)
+	providerData, ok := req.ProviderData.(*squaredupProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Scope Configure Type",
-			fmt.Sprintf("Expected *SquaredUpClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *squaredupProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
 }
 
 func (r *ScopeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -157,13 +157,13 @@ func (r *ScopeResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	scopeID, err := r.client.CreateScope(scopePayload, plan.WorkspaceId.ValueString())
+	scopeID, err := r.client.CreateScope(ctx, scopePayload, plan.WorkspaceId.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to create scope", err.Error())
 		return
 	}
 
-	readScope, err := r.client.GetScope(scopeID, plan.WorkspaceId.ValueString())
+	readScope, err := r.client.GetScope(ctx, scopeID, plan.WorkspaceId.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to read scope", err.Error())
 		return
@@ -187,8 +187,12 @@ func (r *ScopeResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	readScope, err := r.client.GetScope(state.ScopeID.ValueString(), state.WorkspaceId.ValueString())
+	readScope, err := r.client.GetScope(ctx, state.ScopeID.ValueString(), state.WorkspaceId.ValueString())
 	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError("Failed to read scope", err.Error())
 		return
 	}
@@ -227,13 +231,13 @@ func (r *ScopeResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	err = r.client.UpdateScope(plan.ScopeID.ValueString(), scopePayload, plan.WorkspaceId.ValueString())
+	err = r.client.UpdateScope(ctx, plan.ScopeID.ValueString(), scopePayload, plan.WorkspaceId.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to update scope", err.Error())
 		return
 	}
 
-	readScope, err := r.client.GetScope(plan.ScopeID.ValueString(), plan.WorkspaceId.ValueString())
+	readScope, err := r.client.GetScope(ctx, plan.ScopeID.ValueString(), plan.WorkspaceId.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to read scope", err.Error())
 		return
@@ -257,7 +261,7 @@ func (r *ScopeResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	err := r.client.DeleteScope(state.ScopeID.ValueString(), state.WorkspaceId.ValueString())
+	err := r.client.DeleteScope(ctx, state.ScopeID.ValueString(), state.WorkspaceId.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to delete scope", err.Error())
 		return
@@ -298,25 +302,19 @@ func buildFixedScope(plan SquaredUpScope) (ScopeCreate, error) {
 		return scopePayload, fmt.Errorf("data_source_id, types, search_query and advanced_query are not allowed for fixed scope")
 	}
 
-	query := "g.V().hasId(within("
-	for i, id := range plan.NodeIds {
-		if i != 0 {
-			query += ","
-		}
-		query += fmt.Sprintf("'%s'", id.ValueString())
-	}
-	query += "))"
-
 	nodeIds := make([]string, 0)
 	for _, id := range plan.NodeIds {
 		nodeIds = append(nodeIds, id.ValueString())
 	}
 
+	query := newPrefixedGremlinQuery("ids_"+generateRandomID()).Step(".hasId(within(%s))", nodeIds)
+
 	scopePayload = ScopeCreate{
 		Scope: Scope{
-			Name:    plan.DisplayName.ValueString(),
-			Version: 2,
-			Query:   query,
+			Name:     plan.DisplayName.ValueString(),
+			Version:  2,
+			Query:    query.String(),
+			Bindings: query.Bindings(),
 			QueryDetail: ScopeQueryDetail{
 				IDs: nodeIds,
 			},
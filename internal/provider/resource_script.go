@@ -82,17 +82,17 @@ func (r *ScriptResource) Configure(_ context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*SquaredUpClient)
+	providerData, ok := req.ProviderData.(*squaredupProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected type for provider data",
-			fmt.Sprintf("Expected *SquaredUpClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *squaredupProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
 }
 
 func (r *ScriptResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -110,7 +110,7 @@ func (r *ScriptResource) Create(ctx context.Context, req resource.CreateRequest,
 		},
 	}
 
-	script, err := r.client.CreateScript(scriptPayload)
+	script, err := r.client.CreateScript(ctx, scriptPayload)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create script",
@@ -142,8 +142,12 @@ func (r *ScriptResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	script, err := r.client.GetScript(state.ScriptID.ValueString())
+	script, err := r.client.GetScript(ctx, state.ScriptID.ValueString())
 	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Unable to read script",
 			err.Error(),
@@ -191,7 +195,7 @@ func (r *ScriptResource) Update(ctx context.Context, req resource.UpdateRequest,
 		},
 	}
 
-	err := r.client.UpdateScript(plan.ScriptID.ValueString(), scriptPayload)
+	err := r.client.UpdateScript(ctx, plan.ScriptID.ValueString(), scriptPayload)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to update script",
@@ -200,7 +204,7 @@ func (r *ScriptResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	script, err := r.client.GetScript(plan.ScriptID.ValueString())
+	script, err := r.client.GetScript(ctx, plan.ScriptID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to read script",
@@ -229,7 +233,7 @@ func (r *ScriptResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	err := r.client.DeleteScript(state.ScriptID.ValueString())
+	err := r.client.DeleteScript(ctx, state.ScriptID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to delete script",
@@ -145,17 +145,17 @@ func (r *workspaceResource) Configure(ctx context.Context, req resource.Configur
 		return
 	}
 
-	client, ok := req.ProviderData.(*SquaredUpClient)
+	providerData, ok := req.ProviderData.(*squaredupProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Workspace Configure Type",
-			fmt.Sprintf("Expected *SquaredUpClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *squaredupProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
 }
 
 func (r *workspaceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -168,7 +168,7 @@ func (r *workspaceResource) Create(ctx context.Context, req resource.CreateReque
 
 	workspacePayload := GenerateWorkspacePayload(plan)
 
-	workspaceID, err := r.client.CreateWorkspace(workspacePayload)
+	workspaceID, err := r.client.CreateWorkspace(ctx, workspacePayload)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error making API request to create workspace",
@@ -177,7 +177,7 @@ func (r *workspaceResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	readWorkspace, err := r.client.GetWorkspace(workspaceID)
+	readWorkspace, err := r.client.GetWorkspace(ctx, workspaceID)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error making API request to get workspace",
@@ -205,8 +205,12 @@ func (r *workspaceResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	readWorkspace, err := r.client.GetWorkspace(state.ID.ValueString())
+	readWorkspace, err := r.client.GetWorkspace(ctx, state.ID.ValueString())
 	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error making API request to get workspace",
 			err.Error(),
@@ -234,7 +238,7 @@ func (r *workspaceResource) Update(ctx context.Context, req resource.UpdateReque
 
 	workspacePayload := GenerateWorkspacePayload(plan)
 
-	err := r.client.UpdateWorkspace(plan.ID.ValueString(), workspacePayload)
+	readWorkspace, err := r.client.UpdateWorkspace(ctx, plan.ID.ValueString(), workspacePayload)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error making API request to update workspace",
@@ -243,15 +247,6 @@ func (r *workspaceResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
-	readWorkspace, err := r.client.GetWorkspace(plan.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error making API request to get workspace",
-			err.Error(),
-		)
-		return
-	}
-
 	workspace := GenerateWorkspaceState(readWorkspace)
 	workspace.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 	workspace.WorkspacesLinks = plan.WorkspacesLinks
@@ -271,7 +266,7 @@ func (r *workspaceResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
-	err := r.client.DeleteWorkspace(state.ID.ValueString())
+	err := r.client.DeleteWorkspace(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error making API request to delete workspace",
@@ -317,14 +312,21 @@ func GenerateWorkspacePayload(plan workspace) map[string]interface{} {
 		"properties": map[string]interface{}{
 			"openAccessEnabled":      plan.DashboardSharingEnabled.ValueBool(),
 			"tags":                   tags,
-			"description":            plan.Description.ValueString(),
+			"description":            nil,
+			"type":                   nil,
 			"authorizedEmailDomains": authorizedEmailDomains,
 		},
 	}
 
-	// SquaredUp API doesn't allow empty string for type, so only add it if it's not empty
-	if properties, ok := workspacePayload["properties"].(map[string]interface{}); ok && plan.Type.ValueString() != "" {
-		properties["type"] = plan.Type.ValueString()
+	// Unset properties are sent as null so an update clears them on the
+	// server. SquaredUp API doesn't allow empty string for type.
+	if properties, ok := workspacePayload["properties"].(map[string]interface{}); ok {
+		if plan.Description.ValueString() != "" {
+			properties["description"] = plan.Description.ValueString()
+		}
+		if plan.Type.ValueString() != "" {
+			properties["type"] = plan.Type.ValueString()
+		}
 	}
 
 	return workspacePayload
@@ -127,17 +127,17 @@ func (r *workspaceAlertResource) Configure(ctx context.Context, req resource.Con
 		return
 	}
 
-	client, ok := req.ProviderData.(*SquaredUpClient)
+	providerData, ok := req.ProviderData.(*squaredupProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected provider data type",
-			fmt.Sprintf("Expected *SquaredUpClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *squaredupProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
 }
 
 func (r *workspaceAlertResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -158,7 +158,7 @@ func (r *workspaceAlertResource) Create(ctx context.Context, req resource.Create
 		resp.Diagnostics.AddWarning("Unsupported Attribute", warning)
 	}
 
-	err = r.client.UpdateWorkspace(plan.WorkspaceID.ValueString(), payload)
+	_, err = r.client.UpdateWorkspace(ctx, plan.WorkspaceID.ValueString(), payload)
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating workspace alerts", err.Error())
 		return
@@ -183,8 +183,12 @@ func (r *workspaceAlertResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
-	readWorkspace, err := r.client.GetWorkspace(state.ID.ValueString())
+	readWorkspace, err := r.client.GetWorkspace(ctx, state.ID.ValueString())
 	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError("Error reading workspace", err.Error())
 		return
 	}
@@ -226,7 +230,7 @@ func (r *workspaceAlertResource) Update(ctx context.Context, req resource.Update
 		resp.Diagnostics.AddWarning("Unsupported Attribute", warning)
 	}
 
-	err = r.client.UpdateWorkspace(plan.WorkspaceID.ValueString(), payload)
+	_, err = r.client.UpdateWorkspace(ctx, plan.WorkspaceID.ValueString(), payload)
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating workspace alerts", err.Error())
 		return
@@ -254,7 +258,7 @@ func (r *workspaceAlertResource) Delete(ctx context.Context, req resource.Delete
 		"alertingRules": []interface{}{},
 	}
 
-	err := r.client.UpdateWorkspace(state.ID.ValueString(), payload)
+	_, err := r.client.UpdateWorkspace(ctx, state.ID.ValueString(), payload)
 	if err != nil {
 		resp.Diagnostics.AddError("Error with removing workspace alerts", err.Error())
 		return